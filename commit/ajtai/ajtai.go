@@ -0,0 +1,139 @@
+// Package ajtai implements the SIS-based commitment t = A*s mod q with a
+// short s and a uniform A expanded from a seed.
+//
+// Two different openings s, s' of the same t give A*(s - s') = 0 with
+// ||s - s'|| <= 2*Bound, so binding holds as long as that MSIS instance is
+// hard, see Parameters.MSIS. Hiding needs enough entropy in s for A*s to be
+// close to uniform, which takes M well above N.
+package ajtai
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/estimate"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+const SeedSize = 32
+
+var (
+	ErrParameters = errors.New("ajtai: invalid parameters")
+	ErrOpening    = errors.New("ajtai: invalid opening")
+)
+
+type Parameters struct {
+	// A is N x M
+	N, M int
+	// Coefficients of honest openings are in [-Eta, Eta]
+	Eta int64
+	// Openings are accepted with ||s|| <= Bound in Norm
+	Norm  estimate.Norm
+	Bound float64
+}
+
+func (params *Parameters) check() error {
+	if latticehelper.MainRing == nil {
+		return fmt.Errorf("%w: latticehelper.MainRing is not initialized", ErrParameters)
+	}
+	if params.N < 1 || params.M <= params.N {
+		return fmt.Errorf("%w: A of size %dx%d, it must be wider than tall", ErrParameters, params.N, params.M)
+	}
+	if params.Norm != estimate.InfiniteNorm && params.Norm != estimate.SecondNorm {
+		return fmt.Errorf("%w: %v", ErrParameters, params.Norm)
+	}
+	// Honest openings pass, and s - s' = q*e is not a trivial collision
+	q, _ := latticehelper.MainRing.Modulus().Float64()
+	if params.Eta < 1 || params.Bound < float64(params.Eta) || 2*params.Bound >= q {
+		return fmt.Errorf("%w: need 1 <= Eta <= Bound < q/2", ErrParameters)
+	}
+	return nil
+}
+
+// The MSIS instance binding reduces to, with A in Hermite normal form
+// [I | A'] and A' of size N x (M - N)
+func (params *Parameters) MSIS() estimate.MSIS {
+	return estimate.NewMSIS(latticehelper.MainRing, params.N, params.M-params.N, 2*params.Bound, params.Norm)
+}
+
+type Key struct {
+	Params *Parameters
+	Seed   []byte
+	A      matrix.PolyQMatrix
+}
+
+// A is expanded from seed with latticehelper.GetSampler
+func NewKey(params *Parameters, seed []byte) (*Key, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: %d-byte seed", ErrParameters, len(seed))
+	}
+	sampler, err := latticehelper.GetSampler(seed)
+	if err != nil {
+		return nil, err
+	}
+	a := matrix.NewRandomPolyQMatrix(sampler, params.N, params.M)
+	return &Key{Params: params, Seed: append([]byte(nil), seed...), A: a}, nil
+}
+
+// Key from a fresh seed read from rand, crypto/rand if nil
+func GenerateKey(params *Parameters, rand io.Reader) (*Key, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(latticehelper.RandReader(rand), seed); err != nil {
+		return nil, err
+	}
+	return NewKey(params, seed)
+}
+
+// M polynomials with coefficients in [-Eta, Eta], from rand or crypto/rand
+// if nil
+func (key *Key) SampleOpening(rand io.Reader) (vector.PolyQVector, error) {
+	return vector.NewUniformPolyQVectorFromReader(rand, key.Params.M, key.Params.Eta)
+}
+
+// t = A*s
+func (key *Key) Commit(s vector.PolyQVector) vector.PolyQVector {
+	if s.Length() != key.Params.M {
+		log.Panic("Commit: opening has the wrong length")
+	}
+	return key.A.VecMul(s)
+}
+
+// Checks that s is short and A*s = t
+func (key *Key) VerifyOpening(t, s vector.PolyQVector) error {
+	params := key.Params
+	if t.Length() != params.N || s.Length() != params.M {
+		return fmt.Errorf("%w: wrong dimensions", ErrOpening)
+	}
+	if norm := params.norm(s); norm > params.Bound {
+		return fmt.Errorf("%w: %v %g above %g", ErrOpening, params.Norm, norm, params.Bound)
+	}
+	if !key.A.VecMul(s).Equals(t) {
+		return ErrOpening
+	}
+	return nil
+}
+
+func (params *Parameters) norm(s vector.PolyQVector) float64 {
+	inf := s.InfiniteNorm()
+	if params.Norm == estimate.InfiniteNorm || float64(inf) > params.Bound {
+		return float64(inf)
+	}
+
+	// PolyQVector.SecondNorm adds up infinite norms of the polynomials,
+	// binding needs the norm of all coefficients
+	sum := 0.0
+	for _, p := range s {
+		for _, coeff := range p.NonQ().WithCenteredModulo() {
+			sum += float64(coeff) * float64(coeff)
+		}
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,132 @@
+package ajtai
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/estimate"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+var (
+	infParams = &Parameters{N: 1, M: 3, Eta: 1, Norm: estimate.InfiniteNorm, Bound: 1}
+	// Every honest opening has ||s||_2 <= sqrt(3 * 128)
+	l2Params = &Parameters{N: 1, M: 3, Eta: 1, Norm: estimate.SecondNorm, Bound: 20}
+)
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func TestCommitVerify(t *testing.T) {
+	for _, params := range []*Parameters{infParams, l2Params} {
+		key, err := GenerateKey(params, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := key.SampleOpening(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.InfiniteNorm() > params.Eta {
+			t.Fatalf("%v: sampled opening of norm %d", params.Norm, s.InfiniteNorm())
+		}
+
+		com := key.Commit(s)
+		if err := key.VerifyOpening(com, s); err != nil {
+			t.Fatalf("%v: %v", params.Norm, err)
+		}
+
+		other := s.Add(vector.NewPolyQVectorFromCoeffs([][]int64{{0, 1}, {0}, {0}}))
+		if err := key.VerifyOpening(com, other); !errors.Is(err, ErrOpening) {
+			t.Errorf("%v: other opening: %v", params.Norm, err)
+		}
+		if err := key.VerifyOpening(com[:0], s); !errors.Is(err, ErrOpening) {
+			t.Errorf("%v: empty commitment: %v", params.Norm, err)
+		}
+
+		// Commitments are linear, but the sum is not short any more
+		if err := key.VerifyOpening(com.ScaledByInt(3), s.ScaledByInt(3)); !errors.Is(err, ErrOpening) {
+			t.Errorf("%v: 3*s accepted", params.Norm)
+		}
+	}
+}
+
+func TestNorms(t *testing.T) {
+	key, _ := NewKey(l2Params, make([]byte, SeedSize))
+
+	// All coefficients 1: infinite norm 1, second norm sqrt(384)
+	ones := make([]int64, latticehelper.MainRing.N())
+	for i := range ones {
+		ones[i] = -1
+	}
+	s := vector.PolyQVector{poly.NewPolyQFromCoeffs(ones...), poly.NewPolyQFromCoeffs(ones...), poly.NewPolyQFromCoeffs(ones...)}
+	if n := l2Params.norm(s); math.Abs(n-math.Sqrt(384)) > 1e-9 {
+		t.Errorf("second norm %g", n)
+	}
+	if s.SecondNorm() >= l2Params.norm(s) {
+		t.Error("PolyQVector.SecondNorm is not smaller than the coefficient norm")
+	}
+	if err := key.VerifyOpening(key.Commit(s), s); err != nil {
+		t.Error(err)
+	}
+
+	// Short in the infinite norm, too long in the second norm
+	tight := *l2Params
+	tight.Bound = 19
+	key.Params = &tight
+	if err := key.VerifyOpening(key.Commit(s), s); !errors.Is(err, ErrOpening) {
+		t.Errorf("long opening accepted: %v", err)
+	}
+
+	// A single large coefficient fails before the sum is computed
+	s[0] = poly.NewConstantPolyQ(-25)
+	if n := l2Params.norm(s); n != 25 {
+		t.Errorf("norm %g for a coefficient above the bound", n)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	seed := bytes.Repeat([]byte{5}, SeedSize)
+	k1, err := NewKey(infParams, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, _ := NewKey(infParams, seed)
+	k3, _ := NewKey(infParams, make([]byte, SeedSize))
+	if !k1.A.Equals(k2.A) || k1.A.Equals(k3.A) {
+		t.Error("A is not determined by the seed")
+	}
+	if k1.A.Rows() != infParams.N || k1.A.Cols() != infParams.M {
+		t.Errorf("A is %dx%d", k1.A.Rows(), k1.A.Cols())
+	}
+
+	msis := l2Params.MSIS()
+	if msis.N != 128 || msis.K != 1 || msis.L != 2 || msis.Beta != 40 || msis.Norm != estimate.SecondNorm {
+		t.Errorf("binding instance %+v", msis)
+	}
+}
+
+func TestParameters(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	bad := []*Parameters{
+		{N: 0, M: 3, Eta: 1, Bound: 1},
+		{N: 2, M: 2, Eta: 1, Bound: 1},
+		{N: 1, M: 3, Eta: 2, Bound: 1},
+		{N: 1, M: 3, Eta: 1, Bound: 1 << 31},
+		{N: 1, M: 3, Eta: 1, Bound: 1, Norm: 7},
+	}
+	for _, params := range bad {
+		if _, err := NewKey(params, seed); !errors.Is(err, ErrParameters) {
+			t.Errorf("%+v: %v", *params, err)
+		}
+	}
+	if _, err := NewKey(infParams, seed[1:]); !errors.Is(err, ErrParameters) {
+		t.Errorf("short seed: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package bdlop implements the commitment scheme of Baum, Damgard,
+// Lyubashevsky, Oechsner and Peikert (SCN 2018) on PolyQVector.
+//
+// The public matrices are A1 = [I_n | A1'] and A2 = [0 | I_l | A2'], with A1'
+// and A2' expanded from a seed. A message m of l polynomials is committed to
+// with a short r of k polynomials as
+//
+//	c1 = A1*r
+//	c2 = A2*r + m
+//
+// Zero-knowledge proofs usually only give a relaxed opening (f, r, m) with
+// f*c1 = A1*r and f*c2 = A2*r + f*m, where f is a difference of two
+// challenges. Which f are allowed is up to the DifferenceSet.
+package bdlop
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+const SeedSize = 32
+
+var (
+	ErrParameters = errors.New("bdlop: invalid parameters")
+	ErrOpening    = errors.New("bdlop: invalid opening")
+)
+
+type Parameters struct {
+	// Rows of A1 (module-SIS rank), randomness length and message length
+	N, K, L int
+	// Coefficients of honest randomness are in [-Eta, Eta]
+	Eta int64
+	// Largest coefficient of r accepted by Open and OpenRelaxed. Openings
+	// extracted from proofs need more than Eta.
+	Bound, RelaxedBound int64
+}
+
+func (params *Parameters) check() error {
+	if latticehelper.MainRing == nil {
+		return fmt.Errorf("%w: latticehelper.MainRing is not initialized", ErrParameters)
+	}
+	if params.N < 1 || params.L < 1 || params.K < params.N+params.L+1 {
+		return fmt.Errorf("%w: n = %d, k = %d, l = %d, k must exceed n + l", ErrParameters, params.N, params.K, params.L)
+	}
+	if params.Eta < 1 || params.Bound < params.Eta || params.RelaxedBound < params.Bound {
+		return fmt.Errorf("%w: need 1 <= Eta <= Bound <= RelaxedBound", ErrParameters)
+	}
+	return nil
+}
+
+// Public key of the commitment scheme
+type Key struct {
+	Params *Parameters
+	Seed   []byte
+	A1, A2 matrix.PolyQMatrix
+}
+
+// The random parts A1' and A2' come from one sampler keyed with seed
+func NewKey(params *Parameters, seed []byte) (*Key, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: %d-byte seed", ErrParameters, len(seed))
+	}
+	sampler, err := latticehelper.GetSampler(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	n, k, l := params.N, params.K, params.L
+	a1 := matrix.NewIdentityPolyQMatrix(n).Concat(matrix.NewRandomPolyQMatrix(sampler, n, k-n))
+	a2 := matrix.NewZeroPolyQMatrix(l, n).Concat(matrix.NewIdentityPolyQMatrix(l)).Concat(matrix.NewRandomPolyQMatrix(sampler, l, k-n-l))
+	return &Key{Params: params, Seed: append([]byte(nil), seed...), A1: a1, A2: a2}, nil
+}
+
+// k polynomials with coefficients in [-Eta, Eta], from rand or crypto/rand
+// if nil
+func (key *Key) SampleRandomness(rand io.Reader) (vector.PolyQVector, error) {
+	return vector.NewUniformPolyQVectorFromReader(rand, key.Params.K, key.Params.Eta)
+}
+
+type Commitment struct {
+	C1, C2 vector.PolyQVector
+}
+
+func (key *Key) Commit(msg, r vector.PolyQVector) *Commitment {
+	if msg.Length() != key.Params.L || r.Length() != key.Params.K {
+		log.Panic("Commit: message or randomness has the wrong length")
+	}
+	return &Commitment{C1: key.A1.VecMul(r), C2: key.A2.VecMul(r).Add(msg)}
+}
+
+// Checks that (msg, r) opens com and r is short
+func (key *Key) Open(com *Commitment, msg, r vector.PolyQVector) error {
+	return key.open(com, msg, r, poly.NewConstantPolyQ(1), key.Params.Bound)
+}
+
+// Checks the relaxed opening f*c1 = A1*r, f*c2 = A2*r + f*msg with f in set
+// and r below RelaxedBound
+func (key *Key) OpenRelaxed(com *Commitment, msg, r vector.PolyQVector, f poly.PolyQ, set DifferenceSet) error {
+	if !set.Contains(f) {
+		return fmt.Errorf("%w: f is not in the difference set", ErrOpening)
+	}
+	return key.open(com, msg, r, f, key.Params.RelaxedBound)
+}
+
+func (key *Key) open(com *Commitment, msg, r vector.PolyQVector, f poly.PolyQ, bound int64) error {
+	params := key.Params
+	if com == nil || com.C1.Length() != params.N || com.C2.Length() != params.L ||
+		msg.Length() != params.L || r.Length() != params.K {
+		return fmt.Errorf("%w: wrong dimensions", ErrOpening)
+	}
+	if norm := r.InfiniteNorm(); norm > bound {
+		return fmt.Errorf("%w: randomness norm %d above %d", ErrOpening, norm, bound)
+	}
+	if !com.C1.ScaledByPolyQ(f).Equals(key.A1.VecMul(r)) ||
+		!com.C2.ScaledByPolyQ(f).Equals(key.A2.VecMul(r).Add(msg.ScaledByPolyQ(f))) {
+		return ErrOpening
+	}
+	return nil
+}
+
+// Commitment to the sum of the messages, opened by the sum of the randomness
+func (com *Commitment) Add(other *Commitment) *Commitment {
+	return &Commitment{C1: com.C1.Add(other.C1), C2: com.C2.Add(other.C2)}
+}
+
+func (com *Commitment) Sub(other *Commitment) *Commitment {
+	return &Commitment{C1: com.C1.Sub(other.C1), C2: com.C2.Sub(other.C2)}
+}
+
+// Commitment to c*m, opened by c*r
+func (com *Commitment) ScaledByPolyQ(c poly.PolyQ) *Commitment {
+	return &Commitment{C1: com.C1.ScaledByPolyQ(c), C2: com.C2.ScaledByPolyQ(c)}
+}
+
+// Commitment to m + msg with the same randomness, for adding public values
+func (com *Commitment) AddMessage(msg vector.PolyQVector) *Commitment {
+	return &Commitment{C1: com.C1, C2: com.C2.Add(msg)}
+}
+
+func (com *Commitment) Equals(other *Commitment) bool {
+	return com.C1.Equals(other.C1) && com.C2.Equals(other.C2)
+}
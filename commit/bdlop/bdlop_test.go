@@ -0,0 +1,170 @@
+package bdlop
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Challenges with 8 coefficients +-1, so ||f*r|| <= 2 * 16 * Eta
+var testParams = &Parameters{N: 1, K: 4, L: 2, Eta: 1, Bound: 1, RelaxedBound: 32}
+
+var differences = SparseDifferences{MaxCoeff: 2, MaxWeight: 16}
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func newKey(t *testing.T) *Key {
+	key, err := NewKey(testParams, bytes.Repeat([]byte{3}, SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func sparse(weight int, offset int64) poly.PolyQ {
+	coeffs := make([]int64, latticehelper.MainRing.N())
+	for i := 0; i < weight; i++ {
+		coeffs[(int64(i)*13+offset)%int64(len(coeffs))] = 1 - 2*int64(i%2)
+	}
+	return poly.NewPolyQFromCoeffs(coeffs...)
+}
+
+func TestCommitOpen(t *testing.T) {
+	key := newKey(t)
+	msg := vector.NewPolyQVectorFromCoeffs([][]int64{{1, 2, 3}, {-4}})
+	r, err := key.SampleRandomness(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	com := key.Commit(msg, r)
+	if err := key.Open(com, msg, r); err != nil {
+		t.Fatal(err)
+	}
+
+	other := msg.Add(vector.NewPolyQVectorFromCoeffs([][]int64{{0}, {1}}))
+	if err := key.Open(com, other, r); !errors.Is(err, ErrOpening) {
+		t.Errorf("other message: %v", err)
+	}
+	if err := key.Open(com, msg, r.ScaledByInt(2)); !errors.Is(err, ErrOpening) {
+		t.Errorf("long randomness: %v", err)
+	}
+	if err := key.Open(com, msg, r[1:]); !errors.Is(err, ErrOpening) {
+		t.Errorf("short randomness: %v", err)
+	}
+
+	// Same seed, same matrices
+	again := newKey(t)
+	if !again.A1.Equals(key.A1) || !again.A2.Equals(key.A2) || !again.Commit(msg, r).Equals(com) {
+		t.Error("key is not determined by its seed")
+	}
+}
+
+func TestHomomorphism(t *testing.T) {
+	key := newKey(t)
+	m1 := vector.NewPolyQVectorFromCoeffs([][]int64{{1}, {2}})
+	m2 := vector.NewPolyQVectorFromCoeffs([][]int64{{0, 5}, {-7}})
+	r1, _ := key.SampleRandomness(nil)
+	r2, _ := key.SampleRandomness(nil)
+
+	sum := key.Commit(m1, r1).Add(key.Commit(m2, r2))
+	if !sum.Equals(key.Commit(m1.Add(m2), r1.Add(r2))) {
+		t.Error("sum of commitments is not the commitment to the sum")
+	}
+	diff := key.Commit(m1, r1).Sub(key.Commit(m2, r2))
+	if !diff.Equals(key.Commit(m1.Sub(m2), r1.Sub(r2))) {
+		t.Error("difference of commitments is not the commitment to the difference")
+	}
+
+	c := sparse(8, 0)
+	scaled := key.Commit(m1, r1).ScaledByPolyQ(c)
+	if !scaled.Equals(key.Commit(m1.ScaledByPolyQ(c), r1.ScaledByPolyQ(c))) {
+		t.Error("c*Commit(m, r) is not Commit(c*m, c*r)")
+	}
+	if !key.Commit(m1, r1).AddMessage(m2).Equals(key.Commit(m1.Add(m2), r1)) {
+		t.Error("AddMessage changed the randomness")
+	}
+}
+
+func TestRelaxedOpening(t *testing.T) {
+	key := newKey(t)
+	msg := vector.NewPolyQVectorFromCoeffs([][]int64{{9}, {8, 7}})
+	r, _ := key.SampleRandomness(nil)
+	com := key.Commit(msg, r)
+
+	// What an extractor gets from two accepting transcripts
+	f := sparse(8, 0).Sub(sparse(8, 5))
+	rBar := r.ScaledByPolyQ(f)
+	if err := key.OpenRelaxed(com, msg, rBar, f, differences); err != nil {
+		t.Fatal(err)
+	}
+	if err := key.Open(com, msg, rBar); !errors.Is(err, ErrOpening) {
+		t.Errorf("f*r accepted as an exact opening: %v", err)
+	}
+
+	other := msg.Add(vector.NewPolyQVectorFromCoeffs([][]int64{{1}, {0}}))
+	if err := key.OpenRelaxed(com, other, rBar, f, differences); !errors.Is(err, ErrOpening) {
+		t.Errorf("other message: %v", err)
+	}
+	if err := key.OpenRelaxed(com, msg, rBar, f, SparseDifferences{MaxCoeff: 2, MaxWeight: 4}); !errors.Is(err, ErrOpening) {
+		t.Errorf("f outside of the set: %v", err)
+	}
+	if err := key.OpenRelaxed(com, msg, rBar.ScaledByInt(0), poly.NewPolyQ(), DifferenceSetFunc(func(poly.PolyQ) bool { return true })); err != nil {
+		t.Errorf("custom set: %v", err)
+	}
+
+	// Monomial challenges X^i
+	x3, x10 := poly.NewPolyQFromCoeffs(0, 0, 0, 1), poly.NewPolyQFromCoeffs(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1)
+	f = x3.Sub(x10)
+	if err := key.OpenRelaxed(com, msg, r.ScaledByPolyQ(f), f, MonomialDifferences{}); err != nil {
+		t.Errorf("monomial difference: %v", err)
+	}
+}
+
+func TestDifferenceSets(t *testing.T) {
+	cases := []struct {
+		f        poly.PolyQ
+		sparse   bool
+		monomial bool
+	}{
+		{poly.NewPolyQ(), false, false},
+		{poly.NewPolyQFromCoeffs(1, -1), true, true},
+		{poly.NewPolyQFromCoeffs(-1, 0, -1), true, true},
+		{poly.NewPolyQFromCoeffs(2, -2), true, false},
+		{poly.NewPolyQFromCoeffs(1), true, false},
+		{poly.NewPolyQFromCoeffs(3), false, false},
+		{sparse(17, 0), false, false},
+	}
+	for i, c := range cases {
+		if differences.Contains(c.f) != c.sparse || (MonomialDifferences{}).Contains(c.f) != c.monomial {
+			t.Errorf("case %d: wrong membership", i)
+		}
+	}
+}
+
+func TestParameters(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	bad := []*Parameters{
+		{N: 0, K: 4, L: 2, Eta: 1, Bound: 1, RelaxedBound: 1},
+		{N: 1, K: 3, L: 2, Eta: 1, Bound: 1, RelaxedBound: 1},
+		{N: 1, K: 4, L: 0, Eta: 1, Bound: 1, RelaxedBound: 1},
+		{N: 1, K: 4, L: 2, Eta: 2, Bound: 1, RelaxedBound: 1},
+		{N: 1, K: 4, L: 2, Eta: 1, Bound: 2, RelaxedBound: 1},
+	}
+	for _, params := range bad {
+		if _, err := NewKey(params, seed); !errors.Is(err, ErrParameters) {
+			t.Errorf("%+v: %v", *params, err)
+		}
+	}
+	if _, err := NewKey(testParams, seed[1:]); !errors.Is(err, ErrParameters) {
+		t.Errorf("short seed: %v", err)
+	}
+
+}
@@ -0,0 +1,56 @@
+package bdlop
+
+import (
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Set of challenge differences accepted in relaxed openings
+type DifferenceSet interface {
+	Contains(f poly.PolyQ) bool
+}
+
+// Adapts a function to DifferenceSet
+type DifferenceSetFunc func(f poly.PolyQ) bool
+
+func (fn DifferenceSetFunc) Contains(f poly.PolyQ) bool {
+	return fn(f)
+}
+
+// Nonzero f with centered coefficients in [-MaxCoeff, MaxCoeff] and at most
+// MaxWeight of them nonzero. Differences of challenges with Weight
+// coefficients +-1 are SparseDifferences{MaxCoeff: 2, MaxWeight: 2*Weight}.
+type SparseDifferences struct {
+	MaxCoeff  int64
+	MaxWeight int
+}
+
+func (set SparseDifferences) Contains(f poly.PolyQ) bool {
+	if f.InfiniteNorm() > set.MaxCoeff {
+		return false
+	}
+	weight := 0
+	for _, coeff := range f.Listize() {
+		if coeff != 0 {
+			weight++
+		}
+	}
+	return weight > 0 && weight <= set.MaxWeight
+}
+
+// f = +-X^i +- X^j for i != j, the differences of the monomial challenges
+// +-X^i. 2/f is then short, so relaxed openings can be rescaled.
+type MonomialDifferences struct{}
+
+func (MonomialDifferences) Contains(f poly.PolyQ) bool {
+	nonzero := 0
+	for _, coeff := range f.NonQ().WithCenteredModulo() {
+		switch coeff {
+		case 0:
+		case 1, -1:
+			nonzero++
+		default:
+			return false
+		}
+	}
+	return nonzero == 2
+}
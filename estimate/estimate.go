@@ -0,0 +1,92 @@
+package estimate
+
+import (
+	"fmt"
+	"math"
+)
+
+// Cost model for one call to an SVP oracle in dimension beta (sieving),
+// as used by the Kyber and Dilithium specifications
+type CostModel int
+
+const (
+	// 0.292 * beta, best known classical sieve
+	Classical CostModel = iota
+	// 0.265 * beta, best known quantum sieve
+	Quantum
+)
+
+func (model CostModel) String() string {
+	switch model {
+	case Classical:
+		return "classical"
+	case Quantum:
+		return "quantum"
+	}
+	return fmt.Sprintf("CostModel(%d)", int(model))
+}
+
+// Base-2 logarithm of the core-SVP cost of BKZ with block size beta
+func (model CostModel) SVPCost(beta int) float64 {
+	switch model {
+	case Classical:
+		return float64(beta) * math.Log2(math.Sqrt(3.0/2.0))
+	case Quantum:
+		return float64(beta) * math.Log2(math.Sqrt(13.0/9.0))
+	}
+	panic("SVPCost: unknown cost model")
+}
+
+// Base-2 logarithm of the number of short vectors one sieve call returns
+func sieveVectors(beta int) float64 {
+	return 0.2075 * float64(beta)
+}
+
+// Root Hermite factor reached by BKZ-beta
+func Delta(beta int) float64 {
+	b := float64(beta)
+	return math.Pow(math.Pow(math.Pi*b, 1/b)*b/(2*math.Pi*math.E), 1/(2*b-2))
+}
+
+// Norms the library measures, see PolyQVector.InfiniteNorm and SecondNorm
+type Norm int
+
+const (
+	InfiniteNorm Norm = iota
+	SecondNorm
+)
+
+func (norm Norm) String() string {
+	switch norm {
+	case InfiniteNorm:
+		return "infinite norm"
+	case SecondNorm:
+		return "second norm"
+	}
+	return fmt.Sprintf("Norm(%d)", int(norm))
+}
+
+// Outcome of the cheapest attack found
+type Estimate struct {
+	Attack string
+	// BKZ block size
+	BlockSize int
+	// Number of samples (MLWE) or columns (MSIS) used by the attack
+	Dimension int
+	// Base-2 logarithm of the core-SVP cost
+	Bits float64
+}
+
+func (e Estimate) String() string {
+	return fmt.Sprintf("%s: beta=%d, d=%d, %.1f bits", e.Attack, e.BlockSize, e.Dimension, e.Bits)
+}
+
+// Standard deviation of the uniform distribution on [-eta, eta]
+func SigmaUniform(eta int64) float64 {
+	return math.Sqrt(float64(eta*(eta+1)) / 3)
+}
+
+// Standard deviation of the centered binomial distribution with parameter eta
+func SigmaCBD(eta int64) float64 {
+	return math.Sqrt(float64(eta) / 2)
+}
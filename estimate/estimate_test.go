@@ -0,0 +1,61 @@
+package estimate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Dilithium2 (round 3 specification, Table 1): MLWE with k = l = 4 and
+// eta = 2 needs BKZ-423, i.e. 123 classical and 112 quantum core-SVP bits
+func TestDilithium2MLWE(t *testing.T) {
+	p := MLWE{N: 256, Q: 8380417, K: 4, L: 4, Sigma: SigmaUniform(2)}
+
+	primal := p.Primal(Classical)
+	if primal.BlockSize != 423 {
+		t.Errorf("Expected block size 423 but got %d", primal.BlockSize)
+	}
+	if math.Floor(primal.Bits) != 123 {
+		t.Errorf("Expected 123 classical bits but got %v", primal.Bits)
+	}
+	if math.Floor(p.Primal(Quantum).Bits) != 112 {
+		t.Errorf("Expected 112 quantum bits but got %v", p.Primal(Quantum).Bits)
+	}
+}
+
+// Kyber512 (round 3 specification): about 118 classical core-SVP bits
+func TestKyber512MLWE(t *testing.T) {
+	p := MLWE{N: 256, Q: 3329, K: 2, L: 2, Sigma: SigmaCBD(3)}
+
+	if bits := p.Estimate(Classical).Bits; bits < 115 || bits > 121 {
+		t.Errorf("Expected about 118 bits but got %v", bits)
+	}
+}
+
+func TestDilithium2MSIS(t *testing.T) {
+	gamma1, gamma2 := int64(1<<17), int64((8380417-1)/88)
+	p := MSIS{N: 256, Q: 8380417, K: 4, L: 4, Beta: float64(max(2*gamma1-78, 4*gamma2+2)), Norm: InfiniteNorm}
+
+	if bits := p.Estimate(Classical).Bits; bits < 115 || bits > 130 {
+		t.Errorf("Expected about 123 bits but got %v", bits)
+	}
+
+	// The second norm is at least the infinite norm, so the same bound is easier
+	p.Norm = SecondNorm
+	if p.Estimate(Classical).Bits <= 0 {
+		t.Error("Expected a positive cost")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	latticehelper.InitSingle(256, 8380417)
+	p := NewMLWE(latticehelper.MainRing, 4, 4, SigmaUniform(2))
+
+	if err := p.Check(100, Classical); err != nil {
+		t.Error(err)
+	}
+	if err := p.Check(128, Classical); err == nil {
+		t.Error("Expected error for a 128 bit target")
+	}
+}
@@ -0,0 +1,88 @@
+package estimate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// MLWE instance b = A * s + e over Z_q[X]/(X^N + 1) with A of size k x l,
+// secret and error coefficients of standard deviation Sigma
+type MLWE struct {
+	N     int
+	Q     float64
+	K, L  int
+	Sigma float64
+}
+
+// Takes N and q from the ring, e.g. latticehelper.MainRing
+func NewMLWE(r *ring.Ring, k, l int, sigma float64) MLWE {
+	q, _ := r.Modulus().Float64()
+	return MLWE{N: r.N(), Q: q, K: k, L: l, Sigma: sigma}
+}
+
+// Primal uSVP attack (2016 estimate): BKZ-beta succeeds once
+// sigma * sqrt(beta) <= delta^(2*beta - d - 1) * q^(m/d), d = n + m
+func (p MLWE) Primal(model CostModel) Estimate {
+	n := p.L * p.N
+	best := Estimate{Attack: "primal", Bits: math.Inf(1)}
+
+	for m := 1; m <= p.K*p.N; m++ {
+		d := n + m
+		for beta := 50; beta <= d; beta++ {
+			delta := Delta(beta)
+			lhs := math.Log(p.Sigma) + 0.5*math.Log(float64(beta))
+			rhs := float64(2*beta-d-1)*math.Log(delta) + float64(m)/float64(d)*math.Log(p.Q)
+			if lhs <= rhs {
+				if cost := model.SVPCost(beta); cost < best.Bits {
+					best = Estimate{Attack: "primal", BlockSize: beta, Dimension: m, Bits: cost}
+				}
+				break
+			}
+		}
+	}
+
+	return best
+}
+
+// Dual distinguishing attack: a BKZ-beta dual vector of length l
+// distinguishes with advantage exp(-2 * pi^2 * (l * sigma / q)^2) and
+// sieving provides 2^(0.2075 * beta) such vectors per call
+func (p MLWE) Dual(model CostModel) Estimate {
+	n := p.L * p.N
+	best := Estimate{Attack: "dual", Bits: math.Inf(1)}
+
+	for m := 1; m <= p.K*p.N; m++ {
+		d := n + m
+		for beta := 50; beta <= d; beta++ {
+			logLength := float64(d)*math.Log(Delta(beta)) + float64(n)/float64(d)*math.Log(p.Q)
+			tau := math.Exp(logLength) * p.Sigma / p.Q
+			log2Eps := -2 * math.Pi * math.Pi * tau * tau / math.Ln2
+			log2R := math.Max(0, -2*log2Eps-sieveVectors(beta))
+
+			if cost := model.SVPCost(beta) + log2R; cost < best.Bits {
+				best = Estimate{Attack: "dual", BlockSize: beta, Dimension: m, Bits: cost}
+			}
+		}
+	}
+
+	return best
+}
+
+// Cheaper of the primal and dual attack
+func (p MLWE) Estimate(model CostModel) Estimate {
+	primal, dual := p.Primal(model), p.Dual(model)
+	if dual.Bits < primal.Bits {
+		return dual
+	}
+	return primal
+}
+
+// Returns an error when the cheapest attack costs fewer than target bits
+func (p MLWE) Check(target float64, model CostModel) error {
+	if e := p.Estimate(model); e.Bits < target {
+		return fmt.Errorf("MLWE: %s security below %.0f bits (%v)", model, target, e)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package estimate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// MSIS instance: find non-zero z with [A | I] * z = 0 over Z_q[X]/(X^N + 1),
+// A of size k x l, and the norm of z bounded by Beta
+type MSIS struct {
+	N    int
+	Q    float64
+	K, L int
+	Beta float64
+	Norm Norm
+}
+
+// Takes N and q from the ring, e.g. latticehelper.MainRing
+func NewMSIS(r *ring.Ring, k, l int, beta float64, norm Norm) MSIS {
+	q, _ := r.Modulus().Float64()
+	return MSIS{N: r.N(), Q: q, K: k, L: l, Beta: beta, Norm: norm}
+}
+
+// BKZ-beta on w of the (k + l) * N columns finds vectors of length
+// delta^(w - 1) * q^(k * N / w). For the second norm this has to be below
+// Beta. For the infinite norm the vector is assumed to spread evenly over
+// its w coordinates and sieving is repeated until one of the returned
+// vectors satisfies the bound.
+func (p MSIS) Estimate(model CostModel) Estimate {
+	h := p.K * p.N
+	best := Estimate{Attack: "msis", Bits: math.Inf(1)}
+
+	for w := h + 1; w <= (p.K+p.L)*p.N; w++ {
+		for beta := 50; beta <= w; beta++ {
+			logLength := float64(w-1)*math.Log(Delta(beta)) + float64(h)/float64(w)*math.Log(p.Q)
+			if logLength >= math.Log(p.Q) {
+				// Only the trivial q-vectors are that long
+				continue
+			}
+			length := math.Exp(logLength)
+
+			cost := math.Inf(1)
+			switch p.Norm {
+			case SecondNorm:
+				if length <= p.Beta {
+					cost = model.SVPCost(beta)
+				}
+			case InfiniteNorm:
+				if length <= p.Beta {
+					cost = model.SVPCost(beta)
+					break
+				}
+				sigma := length / math.Sqrt(float64(w))
+				log2Eps := float64(w) * math.Log2(math.Erf(p.Beta/(sigma*math.Sqrt2)))
+				log2R := math.Max(0, -log2Eps-sieveVectors(beta))
+				cost = model.SVPCost(beta) + log2R
+			default:
+				panic("Estimate: unknown norm")
+			}
+
+			if cost < best.Bits {
+				best = Estimate{Attack: "msis", BlockSize: beta, Dimension: w, Bits: cost}
+			}
+		}
+	}
+
+	return best
+}
+
+// Returns an error when the cheapest attack costs fewer than target bits
+func (p MSIS) Check(target float64, model CostModel) error {
+	if e := p.Estimate(model); e.Bits < target {
+		return fmt.Errorf("MSIS: %s security below %.0f bits (%v)", model, target, e)
+	}
+	return nil
+}
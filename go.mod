@@ -5,6 +5,7 @@ go 1.22.2
 require (
 	github.com/raszia/gotiny v0.1.1
 	github.com/tuneinsight/lattigo/v5 v5.0.2
+	golang.org/x/crypto v0.29.0
 )
 
 require (
@@ -14,7 +15,6 @@ require (
 	github.com/kr/text v0.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.9.0 // indirect
-	golang.org/x/crypto v0.29.0 // indirect
 	golang.org/x/exp v0.0.0-20241108190413-2d47ceb2692f // indirect
 	golang.org/x/sys v0.27.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -0,0 +1,42 @@
+// Package compress produces the same gzip-wrapped gotiny encoding as
+// gotiny.MarshalCompress and gotiny.UnmarshalCompress, without their pooled
+// buffers. gotiny returns a slice of a buffer it has already put back into
+// its pool, so concurrent callers can overwrite each other's output.
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/raszia/gotiny"
+)
+
+func Marshal(is ...any) []byte {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(gotiny.Marshal(is...)); err != nil {
+		panic(err)
+	}
+	if err := gz.Close(); err != nil {
+		panic(err)
+	}
+
+	return buf.Bytes()
+}
+
+// Returns the number of bytes consumed by gotiny, 0 on malformed input
+func Unmarshal(data []byte, is ...any) int {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return 0
+	}
+
+	raw, err := io.ReadAll(gz)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0
+	}
+
+	return gotiny.Unmarshal(raw, is...)
+}
@@ -0,0 +1,50 @@
+package compress
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/raszia/gotiny"
+)
+
+func TestCompatibleWithGotiny(t *testing.T) {
+	value := [][]int64{{1, -2, 3}, {4, 5}}
+
+	var fromGotiny [][]int64
+	if Unmarshal(gotiny.MarshalCompress(&value), &fromGotiny) == 0 || !reflect.DeepEqual(value, fromGotiny) {
+		t.Error("Failed to decode gotiny.MarshalCompress output")
+	}
+
+	var fromMarshal [][]int64
+	if gotiny.UnmarshalCompress(Marshal(&value), &fromMarshal) == 0 || !reflect.DeepEqual(value, fromMarshal) {
+		t.Error("gotiny.UnmarshalCompress failed to decode Marshal output")
+	}
+}
+
+func TestConcurrentMarshal(t *testing.T) {
+	values := make([][]int64, 16)
+	outputs := make([][]byte, len(values))
+
+	var wg sync.WaitGroup
+	for i := range values {
+		values[i] = []int64{int64(i), int64(i * i), int64(-i)}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			outputs[i] = Marshal(&values[i])
+		}(i)
+	}
+	wg.Wait()
+
+	for i, output := range outputs {
+		var decoded []int64
+		if Unmarshal(output, &decoded) == 0 || !reflect.DeepEqual(decoded, values[i]) {
+			t.Errorf("Output %d was corrupted", i)
+		}
+	}
+
+	if Unmarshal([]byte("garbage"), &values[0]) != 0 {
+		t.Error("Expected failure on malformed input")
+	}
+}
@@ -0,0 +1,141 @@
+// Package format lays out the entries of vectors and matrices for the
+// styles of poly.Format.
+package format
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Same values as poly.LaTeX, poly.Markdown and poly.Compact
+const (
+	LaTeX = iota + 1
+	Markdown
+	Compact
+)
+
+// Indices of the entries to show out of n, -1 standing for the ellipsis
+// before the last one. max <= 0 shows everything.
+func Indices(n, max int) []int {
+	if max <= 0 || n <= max {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices := make([]int, 0, max+1)
+	for i := 0; i < max-1; i++ {
+		indices = append(indices, i)
+	}
+	return append(indices, -1, n-1)
+}
+
+// Lays out a rows x cols grid, entry(i, j) giving the formatted entries
+func Grid(rows, cols int, entry func(i, j int) string, style, maxRows, maxCols int) string {
+	rowIndices, colIndices := Indices(rows, maxRows), Indices(cols, maxCols)
+
+	switch style {
+	case LaTeX:
+		return latexGrid(rowIndices, colIndices, entry)
+	case Markdown:
+		return markdownGrid(rowIndices, colIndices, entry)
+	}
+	return compactGrid(rowIndices, colIndices, entry)
+}
+
+// Same as a grid with one column, except for Compact, "(a, b, c)"
+func Column(n int, entry func(i int) string, style, maxRows int) string {
+	if style == LaTeX || style == Markdown {
+		return Grid(n, 1, func(i, _ int) string { return entry(i) }, style, maxRows, 0)
+	}
+
+	indices := Indices(n, maxRows)
+	cells := make([]string, len(indices))
+	for k, i := range indices {
+		if i < 0 {
+			cells[k] = "..."
+		} else {
+			cells[k] = entry(i)
+		}
+	}
+	return "(" + strings.Join(cells, ", ") + ")"
+}
+
+func latexGrid(rowIndices, colIndices []int, entry func(i, j int) string) string {
+	lines := make([]string, len(rowIndices))
+	for n, i := range rowIndices {
+		cells := make([]string, len(colIndices))
+		for m, j := range colIndices {
+			switch {
+			case i < 0 && j < 0:
+				cells[m] = `\ddots`
+			case i < 0:
+				cells[m] = `\vdots`
+			case j < 0:
+				cells[m] = `\cdots`
+			default:
+				cells[m] = entry(i, j)
+			}
+		}
+		lines[n] = "  " + strings.Join(cells, " & ")
+	}
+	return "\\begin{pmatrix}\n" + strings.Join(lines, " \\\\\n") + "\n\\end{pmatrix}"
+}
+
+// Table with the row index in the first column and column indices in the header
+func markdownGrid(rowIndices, colIndices []int, entry func(i, j int) string) string {
+	index := func(k int) string {
+		if k < 0 {
+			return "..."
+		}
+		return strconv.Itoa(k)
+	}
+
+	header := []string{""}
+	separator := []string{"---"}
+	for _, j := range colIndices {
+		header = append(header, index(j))
+		separator = append(separator, "---")
+	}
+
+	lines := []string{row(header), row(separator)}
+	for _, i := range rowIndices {
+		cells := []string{index(i)}
+		for _, j := range colIndices {
+			if i < 0 || j < 0 {
+				cells = append(cells, "...")
+			} else {
+				cells = append(cells, entry(i, j))
+			}
+		}
+		lines = append(lines, row(cells))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func row(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// "[[a, b], [c, d]]"
+func compactGrid(rowIndices, colIndices []int, entry func(i, j int) string) string {
+	lines := make([]string, len(rowIndices))
+	for n, i := range rowIndices {
+		if i < 0 {
+			lines[n] = "..."
+			continue
+		}
+		cells := make([]string, len(colIndices))
+		for m, j := range colIndices {
+			if j < 0 {
+				cells[m] = "..."
+			} else {
+				cells[m] = entry(i, j)
+			}
+		}
+		lines[n] = "[" + strings.Join(cells, ", ") + "]"
+	}
+	return "[" + strings.Join(lines, ", ") + "]"
+}
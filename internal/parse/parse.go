@@ -0,0 +1,99 @@
+// Package parse splits the bracketed list syntax used by the String and
+// CoeffString methods of vectors and matrices.
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Returns the text inside "name{...}" for one of names, or inside "[...]"
+func Unwrap(s string, names ...string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		return s[1 : len(s)-1], nil
+	}
+	for _, name := range names {
+		if strings.HasPrefix(s, name+"{") && strings.HasSuffix(s, "}") {
+			return s[len(name)+1 : len(s)-1], nil
+		}
+	}
+	return "", fmt.Errorf("expected [...] or one of %v{...}", names)
+}
+
+// Splits at commas outside brackets and braces. Blank input gives no parts.
+func SplitTopLevel(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := make([]string, 0)
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q at offset %d", c, i)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	return append(parts, s[start:]), nil
+}
+
+// Splits a sequence of bracketed groups, "[...]" or "name{...}", separated
+// by commas or whitespace, as in the rows of a matrix String
+func Groups(s string) ([]string, error) {
+	groups := make([]string, 0)
+	i := 0
+	for {
+		for i < len(s) && (s[i] == ',' || s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+			i++
+		}
+		if i == len(s) {
+			return groups, nil
+		}
+
+		start := i
+		for i < len(s) && s[i] != '[' && s[i] != '{' {
+			if !isIdentByte(s[i]) {
+				return nil, fmt.Errorf("unexpected %q at offset %d", s[i], i)
+			}
+			i++
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("expected a bracketed group at offset %d", start)
+		}
+
+		depth := 0
+		for ; i < len(s); i++ {
+			if s[i] == '[' || s[i] == '{' {
+				depth++
+			} else if s[i] == ']' || s[i] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("unbalanced brackets at offset %d", start)
+		}
+		i++
+		groups = append(groups, s[start:i])
+	}
+}
+
+func isIdentByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_'
+}
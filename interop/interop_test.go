@@ -0,0 +1,167 @@
+package interop
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func TestSageScript(t *testing.T) {
+	script, err := SageScript(
+		Var{"a", poly.NewPolyQFromCoeffs(1, -1, 0, 5)},
+		Var{"e", poly.NewPolyFromCoeffs(0, -3)},
+		Var{"v", vector.PolyQVector{poly.NewPolyQ(), poly.NewPolyQFromCoeffs(2)}},
+		Var{"A", matrix.NewPolyMatrixFromCoeffs([][][]int64{{{1}, {0, 1}}})},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "N = 128\n" +
+		"q = 4294954753\n" +
+		"R.<x> = PolynomialRing(GF(q))\n" +
+		"S.<X> = R.quotient(x^N + 1)\n" +
+		"RZ.<y> = PolynomialRing(ZZ)\n" +
+		"SZ.<Y> = RZ.quotient(y^N + 1)\n" +
+		"a = S(R([1, 4294954752, 0, 5]))\n" +
+		"e = SZ(RZ([0, -3]))\n" +
+		"v = vector(S, [S(0), S(R([2]))])\n" +
+		"A = matrix(SZ, 1, 2, [[SZ(RZ([1])), SZ(RZ([0, 1]))]])\n"
+	if script != expected {
+		t.Errorf("unexpected script:\n%s", script)
+	}
+
+	if _, err := SageScript(Var{"q", poly.NewPoly()}); err == nil {
+		t.Error("header name accepted as variable")
+	}
+	if _, err := SageScript(Var{"a", 3}); err == nil {
+		t.Error("unsupported type accepted")
+	}
+	if _, err := SageExpr(matrix.PolyQMatrix{vector.NewZeroPolyQVector(2), vector.NewZeroPolyQVector(1)}); err == nil {
+		t.Error("ragged matrix exported")
+	}
+}
+
+func TestSageMultipleModuli(t *testing.T) {
+	if err := latticehelper.InitMultiple(128, []uint64{8380417, 4294954753}); err != nil {
+		t.Fatal(err)
+	}
+	defer latticehelper.InitSingle(128, 4294954753)
+
+	q := latticehelper.MainRing.Modulus()
+	minusOne := new(big.Int).Sub(q, big.NewInt(1))
+
+	if header := SageHeader(); !strings.Contains(header, "q = "+q.String()+"\n") || !strings.Contains(header, "Zmod(q)") {
+		t.Errorf("unexpected header:\n%s", header)
+	}
+
+	// -1 and values above the top modulus survive the CRT lift
+	expr, err := SageExpr(vector.PolyQVector{poly.NewPolyQFromCoeffs(1, -1, 0, 5000000000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "vector(S, [S(R([1, " + minusOne.String() + ", 0, 5000000000]))])"; expr != expected {
+		t.Errorf("expected %s, got %s", expected, expr)
+	}
+
+	if _, err := NumPyExpr(poly.NewPolyQ()); err == nil {
+		t.Error("PolyQ of two moduli exported to int64")
+	}
+	if _, err := NumPyExpr(poly.NewPolyFromCoeffs(1)); err != nil {
+		t.Errorf("Poly: %v", err)
+	}
+}
+
+func TestNumPyScript(t *testing.T) {
+	script, err := NumPyScript(
+		Var{"e", vector.NewPolyVectorFromCoeffs([][]int64{{1, -2}})},
+		Var{"A", matrix.NewZeroPolyQMatrix(2, 0)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zeros := strings.Repeat(", 0", 126)
+	expected := "import numpy as np\n\n" +
+		"N = 128\n" +
+		"q = 4294954753\n" +
+		"e = np.array([[1, -2" + zeros + "]], dtype=np.int64)\n" +
+		"A = np.zeros((2, 0, 128), dtype=np.int64)\n"
+	if script != expected {
+		t.Errorf("unexpected script:\n%s", script)
+	}
+
+	expr, _ := NumPyExpr(matrix.NewRandomPolyQMatrix(nil, 2, 3))
+	if strings.Count(expr, "[") != 1+2+2*3 {
+		t.Errorf("matrix array has the wrong nesting: %.60s...", expr)
+	}
+}
+
+func TestParseSageOutput(t *testing.T) {
+	p, err := ParseSagePolyQ("X^3 - 2*X + 1")
+	if err != nil || !p.Equals(poly.NewPolyQFromCoeffs(1, -2, 0, 1)) {
+		t.Errorf("quotient element not parsed: %v", err)
+	}
+
+	e, err := ParseSagePoly("-ybar^2 + 7")
+	if err != nil || !e.Equals(poly.NewPolyFromCoeffs(7, 0, -1)) {
+		t.Errorf("default variable name not parsed: %v", err)
+	}
+
+	last := make([]int64, 128)
+	last[127] = -1
+	v, err := ParseSagePolyQVector("(X + 1, 0, 4294954752*X^127)")
+	if err != nil || !v.Equals(vector.PolyQVector{poly.NewPolyQFromCoeffs(1, 1), poly.NewPolyQ(), poly.NewPolyQFromCoeffs(last...)}) {
+		t.Errorf("vector not parsed: %v", err)
+	}
+
+	m, err := ParseSagePolyMatrix("[ Y - 1     -2]\n[     3 -Y^2 + Y]")
+	expected := matrix.NewPolyMatrixFromCoeffs([][][]int64{{{-1, 1}, {-2}}, {{3}, {0, 1, -1}}})
+	if err != nil || !m.Equals(expected) {
+		t.Errorf("aligned matrix not parsed: %v %v", m, err)
+	}
+
+	m, err = ParseSagePolyMatrix("[[Y - 1, -2], [3, -Y^2 + Y]]")
+	if err != nil || !m.Equals(expected) {
+		t.Errorf("nested list not parsed: %v", err)
+	}
+
+	for _, s := range []string{"[1 2]\n[3]", "(1, 2", "[1 2]\n3 4", "[X^]"} {
+		if _, err := ParseSagePolyQMatrix(s); !errors.Is(err, poly.ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", s, err)
+		}
+	}
+}
+
+func TestSageRoundTrip(t *testing.T) {
+	mat := matrix.NewRandomPolyQMatrix(nil, 2, 2)
+
+	// Sage prints quotient elements with the generator name, xbar by default
+	var sb strings.Builder
+	for _, row := range mat {
+		entries := make([]string, len(row))
+		for j, p := range row {
+			entries[j] = strings.ReplaceAll(p.String(), "x", "xbar")
+		}
+		sb.WriteString("[" + strings.Join(entries, "   ") + "]\n")
+	}
+
+	parsed, err := ParseSagePolyQMatrix(sb.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.Equals(mat) {
+		t.Error("Sage matrix output round trip failed")
+	}
+}
@@ -0,0 +1,110 @@
+package interop
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// NumPy expression for value: an int64 array of shape (N,) for polynomials,
+// (length, N) for vectors and (rows, cols, N) for matrices. PolyQ
+// coefficients are in [0, q), so PolyQ values of rings with several moduli,
+// whose q does not fit an int64, are refused.
+func NumPyExpr(value any) (string, error) {
+	n := latticehelper.MainRing.N()
+
+	switch value.(type) {
+	case poly.PolyQ, vector.PolyQVector, matrix.PolyQMatrix:
+		if latticehelper.MainRing.Level() > 0 {
+			return "", fmt.Errorf("interop: %T of a ring with %d moduli does not fit int64", value, latticehelper.MainRing.Level()+1)
+		}
+	}
+
+	switch v := value.(type) {
+	case poly.Poly:
+		return numpyArray(intList(v)), nil
+	case poly.PolyQ:
+		return numpyArray(intList(v.Listize())), nil
+	case vector.PolyVector:
+		if len(v) == 0 {
+			return numpyZeros(0, n), nil
+		}
+		return numpyArray(nestedList(len(v), func(i int) string { return intList(v[i]) })), nil
+	case vector.PolyQVector:
+		if len(v) == 0 {
+			return numpyZeros(0, n), nil
+		}
+		return numpyArray(nestedList(len(v), func(i int) string { return intList(v[i].Listize()) })), nil
+	case matrix.PolyMatrix:
+		return numpyMatrix(len(v), func(i int) int { return len(v[i]) }, func(i, j int) []int64 { return v[i][j] })
+	case matrix.PolyQMatrix:
+		return numpyMatrix(len(v), func(i int) int { return len(v[i]) }, func(i, j int) []int64 { return v[i][j].Listize() })
+	}
+	return "", fmt.Errorf("interop: unsupported type %T", value)
+}
+
+// Python script importing NumPy and defining N, q and every variable
+func NumPyScript(vars ...Var) (string, error) {
+	r := latticehelper.MainRing
+
+	var sb strings.Builder
+	sb.WriteString("import numpy as np\n\n")
+	fmt.Fprintf(&sb, "N = %d\n", r.N())
+	fmt.Fprintf(&sb, "q = %s\n", r.Modulus())
+	for _, v := range vars {
+		if err := checkName(v.Name); err != nil {
+			return "", err
+		}
+		expr, err := NumPyExpr(v.Value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", v.Name, err)
+		}
+		sb.WriteString(v.Name + " = " + expr + "\n")
+	}
+	return sb.String(), nil
+}
+
+func numpyArray(list string) string {
+	return "np.array(" + list + ", dtype=np.int64)"
+}
+
+func numpyZeros(shape ...int) string {
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = fmt.Sprint(d)
+	}
+	return "np.zeros((" + strings.Join(dims, ", ") + "), dtype=np.int64)"
+}
+
+func nestedList(length int, entry func(int) string) string {
+	entries := make([]string, length)
+	for i := range entries {
+		entries[i] = entry(i)
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
+
+func numpyMatrix(rows int, rowLength func(int) int, entry func(int, int) []int64) (string, error) {
+	n := latticehelper.MainRing.N()
+	if rows == 0 {
+		return numpyZeros(0, 0, n), nil
+	}
+
+	cols := rowLength(0)
+	for i := 1; i < rows; i++ {
+		if rowLength(i) != cols {
+			return "", fmt.Errorf("interop: row %d has %d entries, row 0 has %d", i, rowLength(i), cols)
+		}
+	}
+	if cols == 0 {
+		return numpyZeros(rows, 0, n), nil
+	}
+
+	return numpyArray(nestedList(rows, func(i int) string {
+		return nestedList(cols, func(j int) string { return intList(entry(i, j)) })
+	})), nil
+}
@@ -0,0 +1,173 @@
+// Package interop exports polynomials, vectors and matrices to SageMath and
+// NumPy scripts for cross-validation, and reads values printed by Sage back.
+package interop
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Named value of a script. Value is one of poly.Poly, poly.PolyQ,
+// vector.PolyVector, vector.PolyQVector, matrix.PolyMatrix or matrix.PolyQMatrix.
+type Var struct {
+	Name  string
+	Value any
+}
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Names defined by the script headers
+var reserved = map[string]bool{
+	"N": true, "q": true, "R": true, "S": true, "RZ": true, "SZ": true,
+	"x": true, "X": true, "y": true, "Y": true, "np": true,
+}
+
+func checkName(name string) error {
+	if !identifier.MatchString(name) || reserved[name] {
+		return fmt.Errorf("interop: %q is not a usable variable name", name)
+	}
+	return nil
+}
+
+// Defines the rings of latticehelper.MainRing: S = GF(q)[x]/(x^N + 1) for
+// PolyQ values, printed in X, and SZ = ZZ[y]/(y^N + 1) for Poly values,
+// printed in Y. A ring with several moduli uses Zmod of their product, and
+// PolyQ coefficients are CRT-lifted to it.
+func SageHeader() string {
+	r := latticehelper.MainRing
+	field := "GF(q)"
+	if r.Level() > 0 {
+		field = "Zmod(q)"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "N = %d\n", r.N())
+	fmt.Fprintf(&sb, "q = %s\n", r.Modulus())
+	fmt.Fprintf(&sb, "R.<x> = PolynomialRing(%s)\n", field)
+	sb.WriteString("S.<X> = R.quotient(x^N + 1)\n")
+	sb.WriteString("RZ.<y> = PolynomialRing(ZZ)\n")
+	sb.WriteString("SZ.<Y> = RZ.quotient(y^N + 1)\n")
+	return sb.String()
+}
+
+// Sage expression for value, to be evaluated after SageHeader
+func SageExpr(value any) (string, error) {
+	switch v := value.(type) {
+	case poly.Poly:
+		return sagePoly("SZ", "RZ", v), nil
+	case poly.PolyQ:
+		return sagePolyQ(v), nil
+	case vector.PolyVector:
+		return sageVector("SZ", len(v), func(i int) string { return sagePoly("SZ", "RZ", v[i]) }), nil
+	case vector.PolyQVector:
+		return sageVector("S", len(v), func(i int) string { return sagePolyQ(v[i]) }), nil
+	case matrix.PolyMatrix:
+		return sageMatrix("SZ", len(v), func(i int) int { return len(v[i]) }, func(i, j int) string {
+			return sagePoly("SZ", "RZ", v[i][j])
+		})
+	case matrix.PolyQMatrix:
+		return sageMatrix("S", len(v), func(i int) int { return len(v[i]) }, func(i, j int) string {
+			return sagePolyQ(v[i][j])
+		})
+	}
+	return "", fmt.Errorf("interop: unsupported type %T", value)
+}
+
+// Ready-to-run Sage script defining the rings and every variable
+func SageScript(vars ...Var) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(SageHeader())
+	for _, v := range vars {
+		if err := checkName(v.Name); err != nil {
+			return "", err
+		}
+		expr, err := SageExpr(v.Value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", v.Name, err)
+		}
+		sb.WriteString(v.Name + " = " + expr + "\n")
+	}
+	return sb.String(), nil
+}
+
+// Trailing zero coefficients are left out
+func sagePoly(quotient, base string, coeffs []int64) string {
+	last := len(coeffs) - 1
+	for last >= 0 && coeffs[last] == 0 {
+		last--
+	}
+	if last < 0 {
+		return quotient + "(0)"
+	}
+	return quotient + "(" + base + "(" + intList(coeffs[:last+1]) + "))"
+}
+
+// Listize only gives the residues of the top modulus, so the coefficients
+// are lifted to [0, q) with q the product of all moduli
+func sagePolyQ(p poly.PolyQ) string {
+	r := latticehelper.MainRing
+	coeffs := make([]*big.Int, r.N())
+	r.PolyToBigint(p.Poly, 1, coeffs)
+
+	last := len(coeffs) - 1
+	for last >= 0 && coeffs[last].Sign() == 0 {
+		last--
+	}
+	if last < 0 {
+		return "S(0)"
+	}
+	list := make([]string, last+1)
+	for i := range list {
+		list[i] = coeffs[i].String()
+	}
+	return "S(R([" + strings.Join(list, ", ") + "]))"
+}
+
+func sageVector(ring string, length int, entry func(int) string) string {
+	entries := make([]string, length)
+	for i := range entries {
+		entries[i] = entry(i)
+	}
+	return "vector(" + ring + ", [" + strings.Join(entries, ", ") + "])"
+}
+
+func sageMatrix(ring string, rows int, rowLength func(int) int, entry func(int, int) string) (string, error) {
+	if rows == 0 {
+		return "matrix(" + ring + ", 0, 0)", nil
+	}
+
+	cols := rowLength(0)
+	rowStrings := make([]string, rows)
+	for i := range rowStrings {
+		if rowLength(i) != cols {
+			return "", fmt.Errorf("interop: row %d has %d entries, row 0 has %d", i, rowLength(i), cols)
+		}
+		entries := make([]string, cols)
+		for j := range entries {
+			entries[j] = entry(i, j)
+		}
+		rowStrings[i] = "[" + strings.Join(entries, ", ") + "]"
+	}
+	return fmt.Sprintf("matrix(%s, %d, %d, [%s])", ring, rows, cols, strings.Join(rowStrings, ", ")), nil
+}
+
+func intList(coeffs []int64) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, coeff := range coeffs {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.FormatInt(coeff, 10))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
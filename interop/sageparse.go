@@ -0,0 +1,152 @@
+package interop
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper/internal/parse"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Reads a quotient ring element as Sage prints it, e.g. "X^5 - 2*X + 1" or
+// "xbar^2 + 3", or a coefficient list. Any variable name is accepted.
+func ParseSagePolyQ(s string) (poly.PolyQ, error) {
+	return poly.ParsePolyQ(normalizeVariable(s))
+}
+
+func ParseSagePoly(s string) (poly.Poly, error) {
+	return poly.ParsePoly(normalizeVariable(s))
+}
+
+// Reads a Sage vector, "(p0, p1, ...)", or a list "[p0, p1, ...]"
+func ParseSagePolyQVector(s string) (vector.PolyQVector, error) {
+	return parseSageVector(s, ParseSagePolyQ)
+}
+
+func ParseSagePolyVector(s string) (vector.PolyVector, error) {
+	return parseSageVector(s, ParseSagePoly)
+}
+
+// Reads a Sage matrix, one "[p00 p01 ...]" row per line with entries
+// aligned by spaces, or a nested list "[[p00, p01, ...], ...]"
+func ParseSagePolyQMatrix(s string) (matrix.PolyQMatrix, error) {
+	rows, err := parseSageMatrix(s, ParseSagePolyQ)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(matrix.PolyQMatrix, len(rows))
+	for i, row := range rows {
+		ret[i] = row
+	}
+	return ret, nil
+}
+
+func ParseSagePolyMatrix(s string) (matrix.PolyMatrix, error) {
+	rows, err := parseSageMatrix(s, ParseSagePoly)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(matrix.PolyMatrix, len(rows))
+	for i, row := range rows {
+		ret[i] = row
+	}
+	return ret, nil
+}
+
+// Replaces every identifier by x, the variable poly.ParsePoly understands
+func normalizeVariable(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); {
+		if isLetter(s[i]) {
+			sb.WriteByte('x')
+			for i < len(s) && (isLetter(s[i]) || s[i] >= '0' && s[i] <= '9') {
+				i++
+			}
+			continue
+		}
+		sb.WriteByte(s[i])
+		i++
+	}
+	return sb.String()
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func parseSageVector[T any](s string, parseEntry func(string) (T, error)) ([]T, error) {
+	s = strings.TrimSpace(s)
+	if !(strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")) && !(strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]")) {
+		return nil, fmt.Errorf("%w: expected (...) or [...]", poly.ErrSyntax)
+	}
+
+	entries, err := parse.SplitTopLevel(s[1 : len(s)-1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+	}
+
+	ret := make([]T, len(entries))
+	for i, entry := range entries {
+		if ret[i], err = parseEntry(entry); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+	return ret, nil
+}
+
+func parseSageMatrix[T any](s string, parseEntry func(string) (T, error)) ([][]T, error) {
+	s = strings.TrimSpace(s)
+	if s == "[]" {
+		return nil, nil
+	}
+
+	var rows []string
+	if strings.HasPrefix(s, "[[") || strings.HasPrefix(s, "[(") {
+		inner, err := parse.Unwrap(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+		}
+		if rows, err = parse.SplitTopLevel(inner); err != nil {
+			return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+		}
+	} else {
+		for _, line := range strings.Split(s, "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "[") || !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%w: matrix row %q is not in brackets", poly.ErrSyntax, line)
+			}
+			rows = append(rows, "["+strings.Join(splitAlignedRow(line[1:len(line)-1]), ", ")+"]")
+		}
+	}
+
+	ret := make([][]T, len(rows))
+	for i, row := range rows {
+		var err error
+		if ret[i], err = parseSageVector(row, parseEntry); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i, err)
+		}
+		if len(ret[i]) != len(ret[0]) {
+			return nil, fmt.Errorf("%w: row %d has %d entries, row 0 has %d", poly.ErrSyntax, i, len(ret[i]), len(ret[0]))
+		}
+	}
+	return ret, nil
+}
+
+// Sage separates the entries of a matrix row by spaces only. A lone + or -
+// joins the fields around it into one entry, "-1" on its own starts a new one.
+func splitAlignedRow(row string) []string {
+	entries := make([]string, 0)
+	joinNext := false
+	for _, field := range strings.Fields(row) {
+		isOperator := field == "+" || field == "-"
+		if len(entries) > 0 && (isOperator || joinNext) {
+			entries[len(entries)-1] += " " + field
+		} else {
+			entries = append(entries, field)
+		}
+		joinNext = isOperator
+	}
+	return entries
+}
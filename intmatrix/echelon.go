@@ -0,0 +1,110 @@
+package intmatrix
+
+import (
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Reduced row echelon form modulo q together with the pivot column of every
+// non-zero row. Pivots must be invertible, so q is expected to be prime;
+// for composite q a column whose entries are all non-units is skipped.
+func (mat IntMatrix) RowEchelonMod(q int64) (IntMatrix, []int) {
+	ret := mat.Mod(q)
+	pivots := make([]int, 0, min(mat.Rows(), mat.Cols()))
+
+	row := 0
+	for col := 0; col < ret.Cols() && row < ret.Rows(); col++ {
+		pivot := -1
+		for i := row; i < ret.Rows(); i++ {
+			if ret[i][col] != 0 && gcd(ret[i][col], q) == 1 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+
+		ret[row], ret[pivot] = ret[pivot], ret[row]
+
+		inv := latticehelper.InvMod(ret[row][col], q)
+		for j := col; j < ret.Cols(); j++ {
+			ret[row][j] = mulMod(ret[row][j], inv, q)
+		}
+
+		for i := 0; i < ret.Rows(); i++ {
+			if i == row || ret[i][col] == 0 {
+				continue
+			}
+			factor := q - ret[i][col]
+			for j := col; j < ret.Cols(); j++ {
+				ret[i][j] = addMod(ret[i][j], mulMod(factor, ret[row][j], q), q)
+			}
+		}
+
+		pivots = append(pivots, col)
+		row++
+	}
+
+	return ret, pivots
+}
+
+func (mat IntMatrix) RankMod(q int64) int {
+	_, pivots := mat.RowEchelonMod(q)
+	return len(pivots)
+}
+
+// Determinant modulo prime q
+func (mat IntMatrix) DeterminantMod(q int64) int64 {
+	if mat.Rows() != mat.Cols() {
+		log.Panic("DeterminantMod: matrix is not square")
+	}
+
+	a := mat.Mod(q)
+	n := a.Rows()
+	det := int64(1)
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for i := col; i < n; i++ {
+			if a[i][col] != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			return 0
+		}
+
+		if pivot != col {
+			a[col], a[pivot] = a[pivot], a[col]
+			det = latticehelper.PositiveMod(-det, q)
+		}
+
+		det = mulMod(det, a[col][col], q)
+
+		inv := latticehelper.InvMod(a[col][col], q)
+		for i := col + 1; i < n; i++ {
+			if a[i][col] == 0 {
+				continue
+			}
+			factor := mulMod(q-a[i][col], inv, q)
+			for j := col; j < n; j++ {
+				a[i][j] = addMod(a[i][j], mulMod(factor, a[col][j], q), q)
+			}
+		}
+	}
+
+	return det
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,230 @@
+package intmatrix
+
+import (
+	"fmt"
+	"log"
+	"math/bits"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Integer matrix, typically the flat (SIS/LWE) view of a module matrix
+// as produced by matrix.BigToeplitz
+type IntMatrix [][]int64
+
+func NewZeroIntMatrix(rows, cols int) IntMatrix {
+	mat := make(IntMatrix, rows)
+	for i := range mat {
+		mat[i] = make([]int64, cols)
+	}
+	return mat
+}
+
+func NewIdentityIntMatrix(size int) IntMatrix {
+	mat := NewZeroIntMatrix(size, size)
+	for i := 0; i < size; i++ {
+		mat[i][i] = 1
+	}
+	return mat
+}
+
+func (mat IntMatrix) Rows() int {
+	return len(mat)
+}
+
+func (mat IntMatrix) Cols() int {
+	if len(mat) == 0 {
+		return 0
+	}
+	return len(mat[0])
+}
+
+func (mat IntMatrix) Copy() IntMatrix {
+	ret := make(IntMatrix, mat.Rows())
+	for i, row := range mat {
+		ret[i] = make([]int64, len(row))
+		copy(ret[i], row)
+	}
+	return ret
+}
+
+func (mat IntMatrix) String() string {
+	var sb strings.Builder
+	sb.WriteString("IntMatrix{\n")
+	for _, row := range mat {
+		sb.WriteString("\t" + fmt.Sprint(row) + "\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (mat IntMatrix) Transposed() IntMatrix {
+	ret := NewZeroIntMatrix(mat.Cols(), mat.Rows())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[j][i] = entry
+		}
+	}
+	return ret
+}
+
+// Entries reduced to [0, q)
+func (mat IntMatrix) Mod(q int64) IntMatrix {
+	ret := NewZeroIntMatrix(mat.Rows(), mat.Cols())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i][j] = latticehelper.PositiveMod(entry, q)
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) Neg() IntMatrix {
+	return mat.ScaledByInt(-1)
+}
+
+func (mat IntMatrix) ScaledByInt(scalar int64) IntMatrix {
+	ret := NewZeroIntMatrix(mat.Rows(), mat.Cols())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i][j] = entry * scalar
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) Add(other IntMatrix) IntMatrix {
+	if mat.Rows() != other.Rows() || mat.Cols() != other.Cols() {
+		log.Panic("Add: rows and cols of matrices are not equal")
+	}
+
+	ret := NewZeroIntMatrix(mat.Rows(), mat.Cols())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i][j] = entry + other[i][j]
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) Sub(other IntMatrix) IntMatrix {
+	return mat.Add(other.Neg())
+}
+
+func (mat IntMatrix) MatMul(other IntMatrix) IntMatrix {
+	if mat.Cols() != other.Rows() {
+		log.Panic("MatMul: Number of cols in first mat is not equal to number of rows in second mat")
+	}
+
+	ret := NewZeroIntMatrix(mat.Rows(), other.Cols())
+	for i, row := range mat {
+		for k, entry := range row {
+			if entry == 0 {
+				continue
+			}
+			for j, otherEntry := range other[k] {
+				ret[i][j] += entry * otherEntry
+			}
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) VecMul(vec []int64) []int64 {
+	if len(vec) != mat.Cols() {
+		log.Panic("VecMul: vectors don't have the same length")
+	}
+
+	ret := make([]int64, mat.Rows())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i] += entry * vec[j]
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) AddMod(other IntMatrix, q int64) IntMatrix {
+	if mat.Rows() != other.Rows() || mat.Cols() != other.Cols() {
+		log.Panic("AddMod: rows and cols of matrices are not equal")
+	}
+
+	ret := NewZeroIntMatrix(mat.Rows(), mat.Cols())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i][j] = addMod(latticehelper.PositiveMod(entry, q), latticehelper.PositiveMod(other[i][j], q), q)
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) SubMod(other IntMatrix, q int64) IntMatrix {
+	return mat.AddMod(other.Neg(), q)
+}
+
+func (mat IntMatrix) MatMulMod(other IntMatrix, q int64) IntMatrix {
+	if mat.Cols() != other.Rows() {
+		log.Panic("MatMulMod: Number of cols in first mat is not equal to number of rows in second mat")
+	}
+
+	a, b := mat.Mod(q), other.Mod(q)
+	ret := NewZeroIntMatrix(mat.Rows(), other.Cols())
+	for i, row := range a {
+		for k, entry := range row {
+			if entry == 0 {
+				continue
+			}
+			for j, otherEntry := range b[k] {
+				ret[i][j] = addMod(ret[i][j], mulMod(entry, otherEntry, q), q)
+			}
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) VecMulMod(vec []int64, q int64) []int64 {
+	if len(vec) != mat.Cols() {
+		log.Panic("VecMulMod: vectors don't have the same length")
+	}
+
+	ret := make([]int64, mat.Rows())
+	for i, row := range mat {
+		for j, entry := range row {
+			ret[i] = addMod(ret[i], mulMod(
+				latticehelper.PositiveMod(entry, q),
+				latticehelper.PositiveMod(vec[j], q), q), q)
+		}
+	}
+	return ret
+}
+
+func (mat IntMatrix) Equals(other IntMatrix) bool {
+	if mat.Rows() != other.Rows() || mat.Cols() != other.Cols() {
+		return false
+	}
+
+	for i, row := range mat {
+		for j, entry := range row {
+			if entry != other[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Both inputs must already be in [0, q)
+func addMod(a, b, q int64) int64 {
+	ret := a + b
+	if ret >= q || ret < 0 {
+		ret -= q
+	}
+	return ret
+}
+
+// Both inputs must already be in [0, q)
+func mulMod(a, b, q int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int64(bits.Rem64(hi, lo, uint64(q)))
+}
@@ -0,0 +1,64 @@
+package intmatrix
+
+import (
+	"testing"
+)
+
+func TestMatMul(t *testing.T) {
+	a := IntMatrix{{1, 2}, {3, 4}}
+	b := IntMatrix{{5, 6}, {7, 8}}
+
+	expected := IntMatrix{{19, 22}, {43, 50}}
+	if !a.MatMul(b).Equals(expected) {
+		t.Errorf("Expected %v but got %v", expected, a.MatMul(b))
+	}
+
+	expectedMod := IntMatrix{{8, 0}, {10, 6}}
+	if !a.MatMulMod(b, 11).Equals(expectedMod) {
+		t.Errorf("Expected %v but got %v", expectedMod, a.MatMulMod(b, 11))
+	}
+}
+
+func TestRowEchelonAndRank(t *testing.T) {
+	a := IntMatrix{
+		{1, 2, 3},
+		{2, 4, 6},
+		{1, 0, 1},
+	}
+
+	echelon, pivots := a.RowEchelonMod(7)
+	expected := IntMatrix{
+		{1, 0, 1},
+		{0, 1, 1},
+		{0, 0, 0},
+	}
+
+	if !echelon.Equals(expected) {
+		t.Errorf("Expected %v but got %v", expected, echelon)
+	}
+	if len(pivots) != 2 || pivots[0] != 0 || pivots[1] != 1 {
+		t.Errorf("Unexpected pivots %v", pivots)
+	}
+	if a.RankMod(7) != 2 {
+		t.Errorf("Expected rank 2 but got %d", a.RankMod(7))
+	}
+}
+
+func TestDeterminantMod(t *testing.T) {
+	a := IntMatrix{
+		{2, -1, 0},
+		{1, 3, 2},
+		{0, 1, 4},
+	}
+
+	// det = 2*(12-2) + 1*(4-0) = 24
+	if det := a.DeterminantMod(101); det != 24 {
+		t.Errorf("Expected 24 but got %d", det)
+	}
+	if det := a.DeterminantMod(7); det != 3 {
+		t.Errorf("Expected 3 but got %d", det)
+	}
+	if det := (IntMatrix{{1, 2}, {2, 4}}).DeterminantMod(7); det != 0 {
+		t.Errorf("Expected 0 but got %d", det)
+	}
+}
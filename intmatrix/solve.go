@@ -0,0 +1,65 @@
+package intmatrix
+
+import (
+	"errors"
+	"log"
+)
+
+var ErrNoSolution = errors.New("linear system has no solution")
+
+// Some x with mat * x = t mod q, free variables are set to zero.
+// Like RowEchelonMod, q is expected to be prime.
+func (mat IntMatrix) SolveMod(t []int64, q int64) ([]int64, error) {
+	if len(t) != mat.Rows() {
+		log.Panic("SolveMod: length of target is not equal to number of rows")
+	}
+
+	cols := mat.Cols()
+	augmented := make(IntMatrix, mat.Rows())
+	for i, row := range mat {
+		augmented[i] = make([]int64, cols+1)
+		copy(augmented[i], row)
+		augmented[i][cols] = t[i]
+	}
+
+	echelon, pivots := augmented.RowEchelonMod(q)
+
+	ret := make([]int64, cols)
+	for row, col := range pivots {
+		if col == cols {
+			return nil, ErrNoSolution
+		}
+		ret[col] = echelon[row][cols]
+	}
+
+	return ret, nil
+}
+
+// Basis of the right kernel {x : mat * x = 0 mod q}, one vector per row.
+// The result has no rows when the kernel is trivial.
+func (mat IntMatrix) KernelMod(q int64) IntMatrix {
+	echelon, pivots := mat.RowEchelonMod(q)
+
+	isPivot := make([]bool, mat.Cols())
+	for _, col := range pivots {
+		isPivot[col] = true
+	}
+
+	ret := make(IntMatrix, 0, mat.Cols()-len(pivots))
+	for free := 0; free < mat.Cols(); free++ {
+		if isPivot[free] {
+			continue
+		}
+
+		basisVec := make([]int64, mat.Cols())
+		basisVec[free] = 1
+		for row, col := range pivots {
+			if echelon[row][free] != 0 {
+				basisVec[col] = q - echelon[row][free]
+			}
+		}
+		ret = append(ret, basisVec)
+	}
+
+	return ret
+}
@@ -0,0 +1,65 @@
+package intmatrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSolveMod(t *testing.T) {
+	a := IntMatrix{
+		{1, 2, 3},
+		{0, 1, 4},
+	}
+	target := []int64{5, 6}
+
+	x, err := a.SolveMod(target, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := a.VecMulMod(x, 13)
+	for i := range result {
+		if result[i] != target[i] {
+			t.Errorf("Expected %v but got %v", target, result)
+		}
+	}
+}
+
+func TestSolveModInconsistent(t *testing.T) {
+	a := IntMatrix{
+		{1, 2},
+		{2, 4},
+	}
+
+	if _, err := a.SolveMod([]int64{1, 3}, 13); !errors.Is(err, ErrNoSolution) {
+		t.Errorf("Expected ErrNoSolution but got %v", err)
+	}
+}
+
+func TestKernelMod(t *testing.T) {
+	a := IntMatrix{
+		{1, 2, 3, 4},
+		{2, 4, 6, 8},
+		{0, 1, 1, 1},
+	}
+
+	kernel := a.KernelMod(17)
+	if kernel.Rows() != 2 {
+		t.Fatalf("Expected kernel of dimension 2 but got %d", kernel.Rows())
+	}
+	if kernel.RankMod(17) != 2 {
+		t.Error("Kernel basis is not linearly independent")
+	}
+
+	for _, vec := range kernel {
+		for _, entry := range a.VecMulMod(vec, 17) {
+			if entry != 0 {
+				t.Errorf("%v is not in the kernel", vec)
+			}
+		}
+	}
+
+	if NewIdentityIntMatrix(3).KernelMod(17).Rows() != 0 {
+		t.Error("Expected trivial kernel")
+	}
+}
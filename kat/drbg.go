@@ -0,0 +1,114 @@
+package kat
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// Length of the entropy input, personalization string and seed of the DRBG
+const SeedSize = 48
+
+// AES-256 CTR_DRBG without derivation function, as implemented by rng.c of
+// the NIST PQC submission package. Every Read is one randombytes call, so
+// reads have to follow the reference implementation call for call to
+// reproduce its output.
+type DRBG struct {
+	key           [32]byte
+	v             [16]byte
+	reseedCounter int
+}
+
+// Same as randombytes_init. personalization may be nil.
+func NewDRBG(entropy, personalization []byte) (*DRBG, error) {
+	if len(entropy) != SeedSize {
+		return nil, fmt.Errorf("kat: entropy input has %d bytes, expected %d", len(entropy), SeedSize)
+	}
+	if personalization != nil && len(personalization) != SeedSize {
+		return nil, fmt.Errorf("kat: personalization string has %d bytes, expected %d", len(personalization), SeedSize)
+	}
+
+	var seedMaterial [SeedSize]byte
+	copy(seedMaterial[:], entropy)
+	for i, b := range personalization {
+		seedMaterial[i] ^= b
+	}
+
+	drbg := &DRBG{}
+	drbg.update(seedMaterial[:])
+	drbg.reseedCounter = 1
+	return drbg, nil
+}
+
+func (drbg *DRBG) block() cipher.Block {
+	// A 32-byte key never fails
+	block, _ := aes.NewCipher(drbg.key[:])
+	return block
+}
+
+func (drbg *DRBG) incrementV() {
+	for i := len(drbg.v) - 1; i >= 0; i-- {
+		drbg.v[i]++
+		if drbg.v[i] != 0 {
+			break
+		}
+	}
+}
+
+func (drbg *DRBG) update(providedData []byte) {
+	block := drbg.block()
+
+	var temp [SeedSize]byte
+	for i := 0; i < 3; i++ {
+		drbg.incrementV()
+		block.Encrypt(temp[16*i:], drbg.v[:])
+	}
+	for i, b := range providedData {
+		temp[i] ^= b
+	}
+
+	copy(drbg.key[:], temp[:32])
+	copy(drbg.v[:], temp[32:])
+}
+
+// Same as randombytes(p, len(p)). Never fails.
+func (drbg *DRBG) Read(p []byte) (int, error) {
+	block := drbg.block()
+
+	var out [16]byte
+	for i := 0; i < len(p); i += 16 {
+		drbg.incrementV()
+		block.Encrypt(out[:], drbg.v[:])
+		copy(p[i:], out[:])
+	}
+
+	drbg.update(nil)
+	drbg.reseedCounter++
+	return len(p), nil
+}
+
+// Uniform sampler over latticehelper.MainRing drawing its randomness from
+// drbg, for NewRandomPolyQ and the vector and matrix variants
+func (drbg *DRBG) UniformSampler() *ring.UniformSampler {
+	return ring.NewUniformSampler(drbg, latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level()))
+}
+
+// Seeds of the first n records of a KAT file, generated as PQCgenKAT does:
+// the DRBG is seeded with the bytes 0, 1, ..., 47 and read 48 bytes per record
+func Seeds(n int) [][]byte {
+	entropy := make([]byte, SeedSize)
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	drbg, _ := NewDRBG(entropy, nil)
+
+	seeds := make([][]byte, n)
+	for i := range seeds {
+		seeds[i] = make([]byte, SeedSize)
+		drbg.Read(seeds[i])
+	}
+	return seeds
+}
@@ -0,0 +1,114 @@
+package kat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func TestSeedsMatchPQCgenKAT(t *testing.T) {
+	f, err := ParseFile("testdata/sample.req")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Records) != 3 || f.Comments[0] != "ML-KEM-768" {
+		t.Fatalf("parsed %d records, comments %q", len(f.Records), f.Comments)
+	}
+
+	for i, seed := range Seeds(3) {
+		if err := f.Records[i].Check("seed", seed); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// Signature KATs read the message right after the seed from the same DRBG
+func TestDRBGReadsAreRandombytesCalls(t *testing.T) {
+	entropy := make([]byte, SeedSize)
+	for i := range entropy {
+		entropy[i] = byte(i)
+	}
+	drbg, _ := NewDRBG(entropy, nil)
+
+	seed, msg := make([]byte, SeedSize), make([]byte, 33)
+	drbg.Read(seed)
+	drbg.Read(msg)
+	if hex.EncodeToString(msg) != "d81c4d8d734fcbfbeade3d3f8a039faa2a2c9957e835ad55b22e75bf57bb556ac8" {
+		t.Errorf("unexpected message %X", msg)
+	}
+
+	if _, err := NewDRBG(entropy[:32], nil); err == nil {
+		t.Error("short entropy input accepted")
+	}
+	if _, err := NewDRBG(entropy, entropy[:1]); err == nil {
+		t.Error("short personalization string accepted")
+	}
+}
+
+func TestWriteToRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("testdata/sample.req")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	n, err := f.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo: %d bytes, %v", n, err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("WriteTo output differs from the file:\n%s", buf.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []string{
+		"seed = 00\n",
+		"count = x\n",
+		"count = 0\nseed\n",
+		"count = 0\nseed = 00\nseed = 01\n",
+	}
+	for _, c := range cases {
+		if _, err := Parse(strings.NewReader(c)); !errors.Is(err, ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", c, err)
+		}
+	}
+
+	f, _ := Parse(strings.NewReader("count = 0\nmlen = 33\nmsg = 0G\n"))
+	rec := f.Records[0]
+	if mlen, err := rec.Int("mlen"); err != nil || mlen != 33 {
+		t.Errorf("mlen: %d, %v", mlen, err)
+	}
+	if _, err := rec.Bytes("msg"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("invalid hex accepted: %v", err)
+	}
+	if _, err := rec.Bytes("pk"); !errors.Is(err, ErrMissing) {
+		t.Errorf("missing field: %v", err)
+	}
+}
+
+func TestUniformSamplerIsDeterministic(t *testing.T) {
+	seed := Seeds(1)[0]
+	a, _ := NewDRBG(seed, nil)
+	b, _ := NewDRBG(seed, nil)
+
+	p := poly.NewRandomPolyQ(a.UniformSampler())
+	if !p.Equals(poly.NewRandomPolyQ(b.UniformSampler())) {
+		t.Error("samplers with the same seed differ")
+	}
+}
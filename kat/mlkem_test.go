@@ -0,0 +1,49 @@
+//go:build go1.24
+
+// crypto/mlkem needs a newer language version than go.mod declares
+
+package kat
+
+import (
+	"crypto/mlkem"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Fills the .req file the way PQCgenKAT_kem fills the .rsp, with the ML-KEM
+// key generation of the standard library, then checks it against itself
+func TestRunKEMKeyGen(t *testing.T) {
+	f, err := ParseFile("testdata/sample.req")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyGen := func(drbg *DRBG) []byte {
+		seed := make([]byte, mlkem.SeedSize)
+		drbg.Read(seed)
+		dk, err := mlkem.NewDecapsulationKey768(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dk.EncapsulationKey().Bytes()
+	}
+
+	err = f.Run(func(rec *Record, drbg *DRBG) error {
+		rec.SetBytes("pk", keyGen(drbg))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Run(func(rec *Record, drbg *DRBG) error { return rec.Check("pk", keyGen(drbg)) }); err != nil {
+		t.Error(err)
+	}
+
+	f.Records[1].SetBytes("pk", []byte{0})
+	err = f.Run(func(rec *Record, drbg *DRBG) error { return rec.Check("pk", keyGen(drbg)) })
+	if !errors.Is(err, ErrMismatch) || !strings.HasPrefix(err.Error(), "count 1:") {
+		t.Errorf("expected a mismatch at count 1, got %v", err)
+	}
+}
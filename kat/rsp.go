@@ -0,0 +1,206 @@
+// Package kat reads and writes NIST-style known-answer test files (.req and
+// .rsp) and provides the AES-256 CTR_DRBG of the NIST PQC harness, so
+// schemes can be checked byte for byte against published KATs.
+package kat
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrSyntax   = errors.New("kat: invalid syntax")
+	ErrMissing  = errors.New("kat: missing field")
+	ErrMismatch = errors.New("kat: known answer mismatch")
+)
+
+// One "count = ..." block. Field names keep their order from the file.
+type Record struct {
+	Count  int
+	names  []string
+	values map[string]string
+}
+
+func NewRecord(count int) *Record {
+	return &Record{Count: count, values: make(map[string]string)}
+}
+
+// Names of the fields after count, in file order
+func (rec *Record) Names() []string {
+	return append([]string(nil), rec.names...)
+}
+
+func (rec *Record) Value(name string) (string, bool) {
+	value, ok := rec.values[name]
+	return value, ok
+}
+
+// Sets a field, appending it if it is new
+func (rec *Record) Set(name, value string) {
+	if _, ok := rec.values[name]; !ok {
+		rec.names = append(rec.names, name)
+	}
+	rec.values[name] = value
+}
+
+// Sets a field to upper case hex, as PQCgenKAT writes it
+func (rec *Record) SetBytes(name string, value []byte) {
+	rec.Set(name, strings.ToUpper(hex.EncodeToString(value)))
+}
+
+// Hex decoded field. An empty field gives an empty slice.
+func (rec *Record) Bytes(name string) ([]byte, error) {
+	value, ok := rec.values[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: count %d has no %s", ErrMissing, rec.Count, name)
+	}
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: count %d, %s: %v", ErrSyntax, rec.Count, name, err)
+	}
+	return b, nil
+}
+
+// Decimal field, such as mlen
+func (rec *Record) Int(name string) (int, error) {
+	value, ok := rec.values[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: count %d has no %s", ErrMissing, rec.Count, name)
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: count %d, %s: %v", ErrSyntax, rec.Count, name, err)
+	}
+	return i, nil
+}
+
+// DRBG seeded with the seed field, as PQCgenKAT does before each record
+func (rec *Record) DRBG() (*DRBG, error) {
+	seed, err := rec.Bytes("seed")
+	if err != nil {
+		return nil, err
+	}
+	return NewDRBG(seed, nil)
+}
+
+// Compares got with the hex field name
+func (rec *Record) Check(name string, got []byte) error {
+	expected, err := rec.Bytes(name)
+	if err != nil {
+		return err
+	}
+	if string(expected) != string(got) {
+		return fmt.Errorf("%w: count %d, %s: got %X, want %X", ErrMismatch, rec.Count, name, got, expected)
+	}
+	return nil
+}
+
+type File struct {
+	// Lines starting with "#", without the "#"
+	Comments []string
+	Records  []*Record
+}
+
+// Reads a .req or .rsp file. Every record starts with "count = n", other
+// lines are "name = value" with the value possibly empty.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	var rec *Record
+
+	scanner := bufio.NewScanner(r)
+	// Signatures of large messages can make lines of several megabytes
+	scanner.Buffer(nil, 1<<26)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "#"):
+			f.Comments = append(f.Comments, strings.TrimSpace(text[1:]))
+			continue
+		}
+
+		name, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: expected name = value", ErrSyntax, line)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		if name == "count" {
+			count, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: count %q", ErrSyntax, line, value)
+			}
+			rec = NewRecord(count)
+			f.Records = append(f.Records, rec)
+			continue
+		}
+		if rec == nil {
+			return nil, fmt.Errorf("%w: line %d: %s before the first count", ErrSyntax, line, name)
+		}
+		if _, ok := rec.values[name]; ok {
+			return nil, fmt.Errorf("%w: line %d: %s repeated in count %d", ErrSyntax, line, name, rec.Count)
+		}
+		rec.Set(name, value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func ParseFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Writes the layout of PQCgenKAT: comments, a blank line, then every record
+// followed by a blank line. Empty fields are written as "name =" like in .req files.
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	for _, comment := range f.Comments {
+		sb.WriteString("# " + comment + "\n")
+	}
+	if len(f.Comments) > 0 {
+		sb.WriteString("\n")
+	}
+	for _, rec := range f.Records {
+		sb.WriteString("count = " + strconv.Itoa(rec.Count) + "\n")
+		for _, name := range rec.names {
+			if value := rec.values[name]; value == "" {
+				sb.WriteString(name + " =\n")
+			} else {
+				sb.WriteString(name + " = " + value + "\n")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// Calls check for every record with a DRBG seeded from the record's seed
+// and stops at the first error
+func (f *File) Run(check func(rec *Record, drbg *DRBG) error) error {
+	for _, rec := range f.Records {
+		drbg, err := rec.DRBG()
+		if err != nil {
+			return err
+		}
+		if err := check(rec, drbg); err != nil {
+			return fmt.Errorf("count %d: %w", rec.Count, err)
+		}
+	}
+	return nil
+}
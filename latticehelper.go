@@ -1,6 +1,9 @@
 package latticehelper
 
 import (
+	"crypto/rand"
+	"io"
+
 	"github.com/tuneinsight/lattigo/v5/ring"
 	"github.com/tuneinsight/lattigo/v5/utils/sampling"
 )
@@ -58,3 +61,11 @@ func GetSampler(seed []byte) (*ring.UniformSampler, error) {
 
 	return us, nil
 }
+
+// r == nil means crypto/rand
+func RandReader(r io.Reader) io.Reader {
+	if r == nil {
+		return rand.Reader
+	}
+	return r
+}
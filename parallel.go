@@ -0,0 +1,21 @@
+package latticehelper
+
+import (
+	"sync/atomic"
+)
+
+var parallelism atomic.Int32
+
+// Number of goroutines used by operations that split their work over
+// independent output polynomials, such as PolyQMatrix.MatMul and VecMul.
+// Values below 1 mean sequential execution, which is also the default.
+func SetParallelism(workers int) {
+	parallelism.Store(int32(workers))
+}
+
+func Parallelism() int {
+	if p := int(parallelism.Load()); p > 1 {
+		return p
+	}
+	return 1
+}
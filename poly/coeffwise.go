@@ -0,0 +1,46 @@
+package poly
+
+import (
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Coefficient-wise product mod q, not ring multiplication. For binary
+// coefficients b, b.CoeffMul(b.Sub(1)) is zero.
+func (poly PolyQ) CoeffMul(inputPolyQ PolyQ) PolyQ {
+	retPoly := NewPolyQ()
+	latticehelper.MainRing.MulCoeffsBarrett(poly.Poly, inputPolyQ.Poly, retPoly.Poly)
+	return retPoly
+}
+
+// Evaluations at the roots of X^N + 1 in lattigo's NTT order. Ring
+// multiplication is the slot-wise product of these.
+func (poly PolyQ) NTTSlots() []int64 {
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	polyNTT := r.NewPoly()
+	r.NTT(poly.Poly, polyNTT)
+
+	return PolyQ{polyNTT}.Listize()
+}
+
+// Inverse of NTTSlots, missing slots are zero
+func NewPolyQFromNTTSlots(slots ...int64) PolyQ {
+	if len(slots) > latticehelper.MainRing.N() {
+		log.Panic("NewPolyQFromNTTSlots: more slots than the ring degree")
+	}
+
+	ret := NewPolyQFromCoeffs(slots...)
+	latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level()).INTT(ret.Poly, ret.Poly)
+	return ret
+}
+
+// Coefficient-wise product, not ring multiplication
+func (coeffs Poly) CoeffMul(inputPoly Poly) Poly {
+	ret := make(Poly, latticehelper.MainRing.N())
+	for i, coeff := range coeffs {
+		ret[i] = coeff * inputPoly[i]
+	}
+	return ret
+}
@@ -0,0 +1,95 @@
+package poly
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Runs every operation from many goroutines at once and compares the
+// results with a sequential run. Meant for `go test -race`.
+func runConcurrently(t *testing.T, ops map[string]func() any) {
+	expected := make(map[string]any, len(ops))
+	for name, op := range ops {
+		expected[name] = op()
+	}
+
+	var wg sync.WaitGroup
+	for name, op := range ops {
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(name string, op func() any) {
+				defer wg.Done()
+				if result := op(); !reflect.DeepEqual(result, expected[name]) {
+					t.Errorf("%s: concurrent result differs from sequential one", name)
+				}
+			}(name, op)
+		}
+	}
+	wg.Wait()
+}
+
+func TestPolyQConcurrentReaders(t *testing.T) {
+	a := NewRandomPolyQ(nil)
+	b := NewRandomPolyQWithMaxInfNorm(nil, 1000)
+	aCopy, bCopy := PolyQ{*a.CopyNew()}, PolyQ{*b.CopyNew()}
+
+	runConcurrently(t, map[string]func() any{
+		"Mul":               func() any { return a.Mul(b) },
+		"CoeffMul":          func() any { return a.CoeffMul(b) },
+		"NTTSlots":          func() any { return a.NTTSlots() },
+		"Add":               func() any { return a.Add(b) },
+		"Sub":               func() any { return a.Sub(b) },
+		"Neg":               func() any { return a.Neg() },
+		"Pow":               func() any { return b.Pow(3) },
+		"ScaledByInt":       func() any { return a.ScaledByInt(-7) },
+		"AddedToFirstCoeff": func() any { return a.AddedToFirstCoeff(5) },
+		"HighBits":          func() any { return a.HighBits(1 << 20) },
+		"Power2Round": func() any {
+			r1, r0 := a.Power2Round(13)
+			return []PolyQ{r1, r0}
+		},
+		"InfiniteNorm":  func() any { return b.InfiniteNorm() },
+		"CanonicalNorm": func() any { return b.CanonicalNorm() },
+		"OperatorNorm":  func() any { return b.OperatorNorm() },
+		"Listize":       func() any { return a.Listize() },
+		"NonQ":          func() any { return a.NonQ() },
+		"String":        func() any { return a.String() },
+		"CoeffString":   func() any { return a.CoeffString() },
+		"Serialize":     func() any { return a.Serialize() },
+		"Equals":        func() any { return a.Equals(b) || a.Equals(aCopy) },
+	})
+
+	if !a.Equals(aCopy) || !b.Equals(bCopy) {
+		t.Error("Read-only operations modified their inputs")
+	}
+}
+
+func TestPolyConcurrentReaders(t *testing.T) {
+	a := NewPolyFromCoeffs(1, -2, 3, 4, -5)
+	b := NewPolyFromCoeffs(7, 8, -9)
+	aCopy, bCopy := NewPolyFromCoeffs(a...), NewPolyFromCoeffs(b...)
+
+	runConcurrently(t, map[string]func() any{
+		"Mul":                func() any { return a.Mul(b) },
+		"Add":                func() any { return a.Add(b) },
+		"Sub":                func() any { return a.Sub(b) },
+		"Neg":                func() any { return a.Neg() },
+		"Pow":                func() any { return b.Pow(3) },
+		"ScaledByInt":        func() any { return a.ScaledByInt(3) },
+		"AddedToFirstCoeff":  func() any { return a.AddedToFirstCoeff(5) },
+		"LowBits":            func() any { return a.LowBits(1 << 10) },
+		"CheckNormBound":     func() any { return a.CheckNormBound(4) },
+		"WithCenteredModulo": func() any { return a.WithCenteredModulo() },
+		"CanonicalNorm":      func() any { return a.CanonicalNorm() },
+		"OperatorNorm":       func() any { return a.OperatorNorm() },
+		"Q":                  func() any { return a.Q() },
+		"String":             func() any { return a.String() },
+		"CoeffString":        func() any { return a.CoeffString() },
+		"Equals":             func() any { return a.Equals(b) },
+	})
+
+	if !a.Equals(aCopy) || !b.Equals(bCopy) {
+		t.Error("Read-only operations modified their inputs")
+	}
+}
@@ -0,0 +1,32 @@
+package poly
+
+// Method set shared by Poly and PolyQ, with T the implementing type itself.
+// Generic code takes [T RingElement[T]] and runs over either ring, see
+// vector.Vector and matrix.Matrix.
+type RingElement[T any] interface {
+	Add(T) T
+	Sub(T) T
+	Neg() T
+	Mul(T) T
+	CoeffMul(T) T
+	Pow(int64) T
+	ScaledByInt(int64) T
+	AddedToFirstCoeff(int64) T
+
+	HighBits(alpha int64) T
+	LowBits(alpha int64) T
+	InfiniteNorm() int64
+	CheckNormBound(bound int64) bool
+	CanonicalNorm() float64
+
+	Equals(T) bool
+	Length() int
+	Listize() []int64
+	CoeffString() string
+	String() string
+}
+
+var (
+	_ RingElement[Poly]  = Poly{}
+	_ RingElement[PolyQ] = PolyQ{}
+)
@@ -0,0 +1,112 @@
+package poly
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Evaluates coefficients at the primitive 2N-th roots of unity
+// w_j = exp(i*pi*(2j+1)/N), which are exactly the roots of X^N + 1.
+// N must be a power of two.
+func canonicalEmbedding(coeffs []int64) []complex128 {
+	n := len(coeffs)
+	if n&(n-1) != 0 {
+		panic("canonicalEmbedding: length must be a power of two")
+	}
+
+	ret := make([]complex128, n)
+	for k, coeff := range coeffs {
+		ret[k] = complex(float64(coeff), 0) * cmplx.Rect(1, math.Pi*float64(k)/float64(n))
+	}
+
+	fft(ret)
+
+	return ret
+}
+
+// In-place iterative radix-2 DFT with positive exponent,
+// X_j = sum_k x_k * exp(2*pi*i*j*k/n)
+func fft(a []complex128) {
+	n := len(a)
+	if n <= 1 {
+		return
+	}
+
+	logN := bits.Len(uint(n)) - 1
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse(uint(i)) >> (bits.UintSize - logN))
+		if i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size >> 1
+		step := cmplx.Rect(1, 2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				u := a[start+k]
+				v := a[start+k+half] * w
+				a[start+k] = u + v
+				a[start+k+half] = u - v
+				w *= step
+			}
+		}
+	}
+}
+
+func euclideanNorm(values []complex128) float64 {
+	sum := 0.0
+	for _, v := range values {
+		abs := cmplx.Abs(v)
+		sum += abs * abs
+	}
+	return math.Sqrt(sum)
+}
+
+func maxAbs(values []complex128) float64 {
+	max := 0.0
+	for _, v := range values {
+		if abs := cmplx.Abs(v); abs > max {
+			max = abs
+		}
+	}
+	return max
+}
+
+func (coeffs Poly) CanonicalEmbedding() []complex128 {
+	return canonicalEmbedding(coeffs)
+}
+
+// Euclidean norm of the canonical embedding, equal to sqrt(N) times
+// the Euclidean norm of the coefficient vector for X^N + 1
+func (coeffs Poly) CanonicalNorm() float64 {
+	return euclideanNorm(coeffs.CanonicalEmbedding())
+}
+
+// Spectral norm of the linear map b -> coeffs * b, i.e. the largest
+// absolute value of the canonical embedding
+func (coeffs Poly) OperatorNorm() float64 {
+	return maxAbs(coeffs.CanonicalEmbedding())
+}
+
+// Coefficients are lifted to the centered range (-q/2, q/2] first
+func (poly PolyQ) CanonicalEmbedding() []complex128 {
+	centered := poly.Listize()
+	for i, coeff := range centered {
+		centered[i] = CenteredModulo(coeff, latticehelper.MainRing.Modulus().Int64())
+	}
+	return canonicalEmbedding(centered)
+}
+
+func (poly PolyQ) CanonicalNorm() float64 {
+	return euclideanNorm(poly.CanonicalEmbedding())
+}
+
+func (poly PolyQ) OperatorNorm() float64 {
+	return maxAbs(poly.CanonicalEmbedding())
+}
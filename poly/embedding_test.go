@@ -0,0 +1,50 @@
+package poly
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+func TestCanonicalEmbeddingIsMultiplicative(t *testing.T) {
+	a := NewRandomPolyQWithMaxInfNorm(nil, 50)
+	b := NewRandomPolyQWithMaxInfNorm(nil, 50)
+
+	embA := a.CanonicalEmbedding()
+	embB := b.CanonicalEmbedding()
+	embAB := a.Mul(b).CanonicalEmbedding()
+
+	for i := range embAB {
+		if cmplx.Abs(embAB[i]-embA[i]*embB[i]) > 1e-6*cmplx.Abs(embAB[i])+1e-6 {
+			t.Fatalf("slot %d: expected %v but got %v", i, embA[i]*embB[i], embAB[i])
+		}
+	}
+}
+
+func TestCanonicalNorm(t *testing.T) {
+	p := NewPolyFromCoeffs(3, -1, 4, 1, -5, 9)
+
+	sum := 0.0
+	for _, coeff := range p {
+		sum += float64(coeff * coeff)
+	}
+	expected := math.Sqrt(float64(latticehelper.MainRing.N()) * sum)
+
+	if math.Abs(p.CanonicalNorm()-expected) > 1e-9*expected {
+		t.Errorf("Expected %v but got %v", expected, p.CanonicalNorm())
+	}
+
+	if math.Abs(p.Q().CanonicalNorm()-expected) > 1e-9*expected {
+		t.Errorf("Expected %v but got %v", expected, p.Q().CanonicalNorm())
+	}
+}
+
+func TestOperatorNormOfMonomial(t *testing.T) {
+	// x^k only rotates coefficients, so every slot has absolute value 1
+	p := NewPolyFromCoeffs(0, 0, 0, 7)
+	if math.Abs(p.OperatorNorm()-7) > 1e-9 {
+		t.Errorf("Expected 7 but got %v", p.OperatorNorm())
+	}
+}
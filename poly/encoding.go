@@ -0,0 +1,70 @@
+package poly
+
+import (
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+// Versioned encoding with the ring fingerprint, see package wire.
+// Serialize keeps the older headerless format.
+func (coeffs Poly) MarshalBinary() ([]byte, error) {
+	if len(coeffs) != latticehelper.MainRing.N() {
+		return nil, fmt.Errorf("MarshalBinary: Poly has %d coefficients, ring degree is %d", len(coeffs), latticehelper.MainRing.N())
+	}
+
+	b := make([]byte, 0, wire.HeaderSize+wire.PolySize())
+	b = wire.AppendHeader(b, wire.TagPoly, 1, 1)
+	return wire.AppendPoly(b, coeffs), nil
+}
+
+func (coeffs *Poly) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPoly)
+	if err != nil {
+		return err
+	}
+	if rows != 1 || cols != 1 {
+		return fmt.Errorf("%w: Poly with dimensions %dx%d", wire.ErrMalformed, rows, cols)
+	}
+	if err := wire.CheckPayload(payload, 1, 1, wire.PolySize()); err != nil {
+		return err
+	}
+
+	ret := NewPoly()
+	if _, err := wire.ReadPoly(payload, ret); err != nil {
+		return err
+	}
+	*coeffs = ret
+	return nil
+}
+
+func (poly PolyQ) MarshalBinary() ([]byte, error) {
+	if err := wire.CheckPolyQ(poly.Coeffs); err != nil {
+		return nil, fmt.Errorf("MarshalBinary: %w", err)
+	}
+
+	b := make([]byte, 0, wire.HeaderSize+wire.PolyQSize())
+	b = wire.AppendHeader(b, wire.TagPolyQ, 1, 1)
+	return wire.AppendPolyQ(b, poly.Coeffs), nil
+}
+
+func (poly *PolyQ) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPolyQ)
+	if err != nil {
+		return err
+	}
+	if rows != 1 || cols != 1 {
+		return fmt.Errorf("%w: PolyQ with dimensions %dx%d", wire.ErrMalformed, rows, cols)
+	}
+	if err := wire.CheckPayload(payload, 1, 1, wire.PolyQSize()); err != nil {
+		return err
+	}
+
+	ret := NewPolyQ()
+	if _, err := wire.ReadPolyQ(payload, ret.Coeffs); err != nil {
+		return err
+	}
+	*poly = ret
+	return nil
+}
@@ -0,0 +1,110 @@
+package poly
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQMarshalBinary(t *testing.T) {
+	p := NewRandomPolyQ(nil)
+
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded PolyQ
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(p) {
+		t.Error("PolyQ binary round trip failed")
+	}
+
+	var wrongType Poly
+	if err := wrongType.UnmarshalBinary(b); !errors.Is(err, wire.ErrType) {
+		t.Errorf("PolyQ encoding decoded as Poly: %v", err)
+	}
+	if err := decoded.UnmarshalBinary(b[:len(b)-1]); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("truncated encoding accepted: %v", err)
+	}
+}
+
+func TestPolyMarshalBinary(t *testing.T) {
+	p := NewRandomPoly()
+
+	b, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Poly
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(p) {
+		t.Error("Poly binary round trip failed")
+	}
+
+	if _, err := Poly([]int64{1, 2}).MarshalBinary(); err == nil {
+		t.Error("Poly of the wrong degree encoded")
+	}
+}
+
+func TestMarshalBinaryRejectsOtherRing(t *testing.T) {
+	b, err := NewRandomPolyQ(nil).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer latticehelper.InitSingle(128, 4294954753)
+	latticehelper.InitSingle(128, 12289)
+
+	var decoded PolyQ
+	if err := decoded.UnmarshalBinary(b); !errors.Is(err, wire.ErrRing) {
+		t.Errorf("encoding from another ring accepted: %v", err)
+	}
+}
+
+func FuzzPolyQUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPolyQ(nil).MarshalBinary()
+	f.Add(b)
+	f.Add(b[:wire.HeaderSize])
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var p PolyQ
+		if p.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded PolyQ does not re-encode to its input")
+		}
+	})
+}
+
+func FuzzPolyUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPoly().MarshalBinary()
+	f.Add(b)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var p Poly
+		if p.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded Poly does not re-encode to its input")
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package poly
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper/internal/format"
+)
+
+type Style int
+
+const (
+	// Math mode LaTeX, "3 - 2x + x^{5}", vectors and matrices as pmatrix
+	LaTeX Style = format.LaTeX
+	// Polynomials in backticks, vectors and matrices as tables with indices
+	Markdown Style = format.Markdown
+	// Plain text on one line, "3 - 2x + x^5"
+	Compact Style = format.Compact
+)
+
+func (style Style) String() string {
+	switch style {
+	case LaTeX:
+		return "LaTeX"
+	case Markdown:
+		return "Markdown"
+	case Compact:
+		return "Compact"
+	}
+	return fmt.Sprintf("Style(%d)", int(style))
+}
+
+// Limits above which output is shortened with an ellipsis before the last
+// term, row or column. Zero means no limit.
+type FormatOptions struct {
+	MaxTerms int
+	MaxRows  int
+	MaxCols  int
+}
+
+var DefaultFormatOptions = FormatOptions{MaxTerms: 8, MaxRows: 8, MaxCols: 8}
+
+// Formats p with centered coefficients and DefaultFormatOptions
+func Format[T Poly | PolyQ](p T, style Style) string {
+	return FormatWithOptions(p, style, DefaultFormatOptions)
+}
+
+func FormatWithOptions[T Poly | PolyQ](p T, style Style, opts FormatOptions) string {
+	var coeffs []int64
+	switch v := any(p).(type) {
+	case Poly:
+		coeffs = v
+	case PolyQ:
+		coeffs = v.NonQ().WithCenteredModulo()
+	}
+
+	text := formatTerms(coeffs, style, opts.MaxTerms)
+	if style == Markdown {
+		return "`" + text + "`"
+	}
+	return text
+}
+
+func formatTerms(coeffs []int64, style Style, maxTerms int) string {
+	exps := make([]int, 0)
+	for i, coeff := range coeffs {
+		if coeff != 0 {
+			exps = append(exps, i)
+		}
+	}
+	if len(exps) == 0 {
+		return "0"
+	}
+
+	var sb strings.Builder
+	for n, k := range format.Indices(len(exps), maxTerms) {
+		if k < 0 {
+			if style == LaTeX {
+				sb.WriteString(` + \cdots`)
+			} else {
+				sb.WriteString(" + ...")
+			}
+			continue
+		}
+
+		i, coeff := exps[k], coeffs[exps[k]]
+		// uint64 keeps the magnitude of math.MinInt64
+		magnitude := uint64(coeff)
+		if coeff < 0 {
+			magnitude = -magnitude
+		}
+		switch {
+		case n == 0 && coeff < 0:
+			sb.WriteString("-")
+		case n > 0 && coeff < 0:
+			sb.WriteString(" - ")
+		case n > 0:
+			sb.WriteString(" + ")
+		}
+
+		if magnitude != 1 || i == 0 {
+			sb.WriteString(strconv.FormatUint(magnitude, 10))
+		}
+		if i == 1 {
+			sb.WriteString("x")
+		} else if i > 1 && style == LaTeX {
+			sb.WriteString("x^{" + strconv.Itoa(i) + "}")
+		} else if i > 1 {
+			sb.WriteString("x^" + strconv.Itoa(i))
+		}
+	}
+	return sb.String()
+}
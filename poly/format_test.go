@@ -0,0 +1,47 @@
+package poly
+
+import "testing"
+
+func TestFormatStyles(t *testing.T) {
+	p := NewPolyQFromCoeffs(3, -2, 0, 0, 0, 1)
+
+	cases := map[Style]string{
+		LaTeX:    "3 - 2x + x^{5}",
+		Markdown: "`3 - 2x + x^5`",
+		Compact:  "3 - 2x + x^5",
+	}
+	for style, expected := range cases {
+		if s := Format(p, style); s != expected {
+			t.Errorf("%v: got %q, want %q", style, s, expected)
+		}
+	}
+
+	if s := Format(NewPolyFromCoeffs(0, -1, -7), Compact); s != "-x - 7x^2" {
+		t.Errorf("leading negative term: %q", s)
+	}
+	if s := Format(NewPoly(), LaTeX); s != "0" {
+		t.Errorf("zero polynomial: %q", s)
+	}
+	if s := Format(NewPolyFromCoeffs(-9223372036854775808), Compact); s != "-9223372036854775808" {
+		t.Errorf("smallest int64: %q", s)
+	}
+}
+
+func TestFormatTruncation(t *testing.T) {
+	coeffs := make([]int64, 128)
+	for i := range coeffs {
+		coeffs[i] = int64(i + 1)
+	}
+	coeffs[127] = -1
+	p := NewPolyFromCoeffs(coeffs...)
+
+	if s := FormatWithOptions(p, LaTeX, FormatOptions{MaxTerms: 3}); s != `1 + 2x + \cdots - x^{127}` {
+		t.Errorf("truncated LaTeX: %q", s)
+	}
+	if s := Format(p, Compact); s != "1 + 2x + 3x^2 + 4x^3 + 5x^4 + 6x^5 + 7x^6 + ... - x^127" {
+		t.Errorf("default truncation: %q", s)
+	}
+	if s := FormatWithOptions(p, Compact, FormatOptions{}); len(s) < 1000 {
+		t.Errorf("zero options truncated the output: %q", s)
+	}
+}
@@ -0,0 +1,105 @@
+package poly
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func (coeffs Poly) MarshalJSON() ([]byte, error) {
+	return coeffs.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+// Poly coefficients are plain integers, opts.Centered does not apply
+func (coeffs Poly) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	return wire.MarshalJSON(wire.TagPoly, []int64(coeffs), opts)
+}
+
+// Accepts the plain array and the metadata form. Shorter arrays are padded
+// with zero coefficients.
+func (coeffs *Poly) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPoly)
+	if err != nil {
+		return err
+	}
+	return coeffs.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (coeffs Poly) MarshalText() ([]byte, error) {
+	return []byte(coeffs.CoeffString()), nil
+}
+
+// Parses the CoeffString form
+func (coeffs *Poly) UnmarshalText(text []byte) error {
+	list, err := parseCoeffList(text)
+	if err != nil {
+		return err
+	}
+
+	ret := NewPoly()
+	copy(ret, list)
+	*coeffs = ret
+	return nil
+}
+
+func (poly PolyQ) MarshalJSON() ([]byte, error) {
+	return poly.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+func (poly PolyQ) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	coeffs := poly.Listize()
+	if opts.Centered {
+		coeffs = poly.NonQ().WithCenteredModulo()
+	}
+	return wire.MarshalJSON(wire.TagPolyQ, coeffs, opts)
+}
+
+// Accepts the plain array and the metadata form, with coefficients in
+// (-q, q). Shorter arrays are padded with zero coefficients.
+func (poly *PolyQ) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPolyQ)
+	if err != nil {
+		return err
+	}
+	return poly.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (poly PolyQ) MarshalText() ([]byte, error) {
+	return []byte(poly.CoeffString()), nil
+}
+
+// Parses the CoeffString form, coefficients may be centered
+func (poly *PolyQ) UnmarshalText(text []byte) error {
+	list, err := parseCoeffList(text)
+	if err != nil {
+		return err
+	}
+
+	q := latticehelper.MainRing.Modulus().Int64()
+	for _, coeff := range list {
+		if coeff <= -q || coeff >= q {
+			return fmt.Errorf("%w: coefficient %d is not in (-q, q)", wire.ErrMalformed, coeff)
+		}
+	}
+
+	*poly = NewPolyQFromCoeffs(list...)
+	return nil
+}
+
+func parseCoeffList(text []byte) ([]int64, error) {
+	var list []int64
+	if err := json.Unmarshal(text, &list); err != nil {
+		return nil, fmt.Errorf("%w: %v", wire.ErrMalformed, err)
+	}
+	if list == nil {
+		return nil, fmt.Errorf("%w: expected a coefficient array", wire.ErrMalformed)
+	}
+	if len(list) > latticehelper.MainRing.N() {
+		return nil, fmt.Errorf("%w: %d coefficients, ring degree is %d", wire.ErrMalformed, len(list), latticehelper.MainRing.N())
+	}
+	return list, nil
+}
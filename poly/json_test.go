@@ -0,0 +1,106 @@
+package poly
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQJSONRoundTrip(t *testing.T) {
+	p := NewRandomPolyQ(nil)
+
+	for _, opts := range []wire.JSONOptions{{}, {Centered: true}, {Metadata: true}, {Centered: true, Metadata: true}} {
+		b, err := p.MarshalJSONWithOptions(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded PolyQ
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		if !decoded.Equals(p) {
+			t.Errorf("%+v: JSON round trip failed", opts)
+		}
+	}
+}
+
+func TestPolyQJSONForms(t *testing.T) {
+	p := NewPolyQFromCoeffs(1, -1)
+
+	b, _ := p.MarshalJSONWithOptions(wire.JSONOptions{Centered: true, Metadata: true})
+	if !strings.HasPrefix(string(b), `{"type":"PolyQ","n":128,"moduli":[4294954753],"centered":true,"coeffs":[1,-1,0,`) {
+		t.Errorf("unexpected metadata form %s", b[:80])
+	}
+
+	b, _ = json.Marshal(p)
+	if !strings.HasPrefix(string(b), "[1,4294954752,0,") {
+		t.Errorf("unexpected plain form %s", b[:20])
+	}
+
+	// Short and centered arrays written by hand
+	var decoded PolyQ
+	if err := json.Unmarshal([]byte("[1, -1]"), &decoded); err != nil || !decoded.Equals(p) {
+		t.Errorf("hand written array not decoded: %v", err)
+	}
+}
+
+func TestPolyQJSONErrors(t *testing.T) {
+	var p PolyQ
+
+	cases := map[string]error{
+		`[4294954753]`: wire.ErrMalformed,
+		`"x"`:          wire.ErrMalformed,
+		`null`:         wire.ErrMalformed,
+		`{"type":"Poly","n":128,"moduli":[4294954753],"coeffs":[]}`:  wire.ErrType,
+		`{"type":"PolyQ","n":256,"moduli":[4294954753],"coeffs":[]}`: wire.ErrRing,
+		`{"type":"PolyQ","n":128,"moduli":[12289],"coeffs":[]}`:      wire.ErrRing,
+		`{"type":"PolyQ","n":128,"moduli":[4294954753]}`:             wire.ErrMalformed,
+		`{"type":"PolyQ","extra":1}`:                                 wire.ErrMalformed,
+	}
+	for input, want := range cases {
+		if err := json.Unmarshal([]byte(input), &p); !errors.Is(err, want) {
+			t.Errorf("%s: got %v, want %v", input, err, want)
+		}
+	}
+
+	if err := json.Unmarshal([]byte("["+strings.Repeat("0,", 128)+"0]"), &p); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("too many coefficients accepted: %v", err)
+	}
+}
+
+func TestCoeffStringParsesBack(t *testing.T) {
+	q := NewRandomPolyQ(nil)
+	var decodedQ PolyQ
+	if err := decodedQ.UnmarshalText([]byte(q.CoeffString())); err != nil || !decodedQ.Equals(q) {
+		t.Errorf("PolyQ CoeffString not parsed back: %v", err)
+	}
+
+	p := NewPolyFromCoeffs(-5, 0, 7)
+	var decoded Poly
+	if err := decoded.UnmarshalText([]byte(p.CoeffString())); err != nil || !decoded.Equals(p) {
+		t.Errorf("Poly CoeffString not parsed back: %v", err)
+	}
+}
+
+func TestPolyJSONRoundTrip(t *testing.T) {
+	p := NewRandomPoly()
+
+	for _, opts := range []wire.JSONOptions{{}, {Metadata: true}} {
+		b, err := p.MarshalJSONWithOptions(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Poly
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equals(p) {
+			t.Errorf("%+v: JSON round trip failed", opts)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package matrix
+
+import (
+	"log"
+
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func checkSliceBounds(rows, cols, r0, r1, c0, c1 int) {
+	if r0 < 0 || r0 > r1 || r1 > rows || c0 < 0 || c0 > c1 || c1 > cols {
+		log.Panicf("Slice: [%d:%d, %d:%d] out of bounds for %dx%d matrix", r0, r1, c0, c1, rows, cols)
+	}
+}
+
+func checkPermutation(perm []int, size int) {
+	if len(perm) != size {
+		log.Panic("Permutation: length does not match the dimension")
+	}
+	seen := make([]bool, size)
+	for _, p := range perm {
+		if p < 0 || p >= size || seen[p] {
+			log.Panic("Permutation: input is not a permutation")
+		}
+		seen[p] = true
+	}
+}
+
+// Rows r0 .. r1-1 and cols c0 .. c1-1. Like Transposed, the result shares
+// the polynomials with mat.
+func (mat PolyQMatrix) Slice(r0, r1, c0, c1 int) PolyQMatrix {
+	checkSliceBounds(mat.Rows(), mat.Cols(), r0, r1, c0, c1)
+
+	ret := make(PolyQMatrix, r1-r0)
+	for i := range ret {
+		ret[i] = mat[r0+i][c0:c1:c1]
+	}
+	return ret
+}
+
+// Splits into columns [0, col) and [col, Cols()), the inverse of Concat
+func (mat PolyQMatrix) SplitCols(col int) (PolyQMatrix, PolyQMatrix) {
+	return mat.Slice(0, mat.Rows(), 0, col), mat.Slice(0, mat.Rows(), col, mat.Cols())
+}
+
+// Splits into rows [0, row) and [row, Rows()), the inverse of BlockCombine
+func (mat PolyQMatrix) SplitRows(row int) (PolyQMatrix, PolyQMatrix) {
+	return mat.Slice(0, row, 0, mat.Cols()), mat.Slice(row, mat.Rows(), 0, mat.Cols())
+}
+
+// Row i of the result is row perm[i] of mat
+func (mat PolyQMatrix) PermutedRows(perm []int) PolyQMatrix {
+	checkPermutation(perm, mat.Rows())
+
+	ret := make(PolyQMatrix, mat.Rows())
+	for i, p := range perm {
+		ret[i] = mat[p]
+	}
+	return ret
+}
+
+// Column j of the result is column perm[j] of mat
+func (mat PolyQMatrix) PermutedCols(perm []int) PolyQMatrix {
+	checkPermutation(perm, mat.Cols())
+
+	ret := make(PolyQMatrix, mat.Rows())
+	for i, polyQVec := range mat {
+		ret[i] = make(vector.PolyQVector, len(perm))
+		for j, p := range perm {
+			ret[i][j] = polyQVec[p]
+		}
+	}
+	return ret
+}
+
+// Block diagonal matrix with the given blocks and zeroes elsewhere
+func NewBlockDiagPolyQMatrix(blocks ...PolyQMatrix) PolyQMatrix {
+	rows, cols := 0, 0
+	for _, block := range blocks {
+		rows += block.Rows()
+		cols += block.Cols()
+	}
+
+	ret := NewZeroPolyQMatrix(rows, cols)
+	r, c := 0, 0
+	for _, block := range blocks {
+		for i, polyQVec := range block {
+			copy(ret[r+i][c:], polyQVec)
+		}
+		r += block.Rows()
+		c += block.Cols()
+	}
+	return ret
+}
+
+// Kronecker product mat ⊗ other: entry (i, j) of mat becomes the block
+// mat[i][j] * other
+func (mat PolyQMatrix) Kronecker(other PolyQMatrix) PolyQMatrix {
+	ret := make(PolyQMatrix, mat.Rows()*other.Rows())
+	for i := range ret {
+		ret[i] = make(vector.PolyQVector, mat.Cols()*other.Cols())
+	}
+
+	for i, polyQVec := range mat {
+		for j, p := range polyQVec {
+			for k, otherVec := range other {
+				for l, o := range otherVec {
+					ret[i*other.Rows()+k][j*other.Cols()+l] = p.Mul(o)
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// Kronecker product mat ⊗ m with an integer matrix, e.g.
+// NewIdentityPolyQMatrix(n).KroneckerInt(intmatrix.IntMatrix{g}) for the
+// gadget matrix I ⊗ g^T
+func (mat PolyQMatrix) KroneckerInt(m intmatrix.IntMatrix) PolyQMatrix {
+	ret := make(PolyQMatrix, mat.Rows()*m.Rows())
+	for i := range ret {
+		ret[i] = make(vector.PolyQVector, mat.Cols()*m.Cols())
+	}
+
+	for i, polyQVec := range mat {
+		for j, p := range polyQVec {
+			for k, row := range m {
+				for l, entry := range row {
+					ret[i*m.Rows()+k][j*m.Cols()+l] = p.ScaledByInt(entry)
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// Rows r0 .. r1-1 and cols c0 .. c1-1. Like Transposed, the result shares
+// the polynomials with mat.
+func (mat PolyMatrix) Slice(r0, r1, c0, c1 int) PolyMatrix {
+	checkSliceBounds(mat.Rows(), mat.Cols(), r0, r1, c0, c1)
+
+	ret := make(PolyMatrix, r1-r0)
+	for i := range ret {
+		ret[i] = mat[r0+i][c0:c1:c1]
+	}
+	return ret
+}
+
+// Splits into columns [0, col) and [col, Cols()), the inverse of Concat
+func (mat PolyMatrix) SplitCols(col int) (PolyMatrix, PolyMatrix) {
+	return mat.Slice(0, mat.Rows(), 0, col), mat.Slice(0, mat.Rows(), col, mat.Cols())
+}
+
+// Splits into rows [0, row) and [row, Rows()), the inverse of BlockCombine
+func (mat PolyMatrix) SplitRows(row int) (PolyMatrix, PolyMatrix) {
+	return mat.Slice(0, row, 0, mat.Cols()), mat.Slice(row, mat.Rows(), 0, mat.Cols())
+}
+
+// Row i of the result is row perm[i] of mat
+func (mat PolyMatrix) PermutedRows(perm []int) PolyMatrix {
+	checkPermutation(perm, mat.Rows())
+
+	ret := make(PolyMatrix, mat.Rows())
+	for i, p := range perm {
+		ret[i] = mat[p]
+	}
+	return ret
+}
+
+// Column j of the result is column perm[j] of mat
+func (mat PolyMatrix) PermutedCols(perm []int) PolyMatrix {
+	checkPermutation(perm, mat.Cols())
+
+	ret := make(PolyMatrix, mat.Rows())
+	for i, polyVec := range mat {
+		ret[i] = make(vector.PolyVector, len(perm))
+		for j, p := range perm {
+			ret[i][j] = polyVec[p]
+		}
+	}
+	return ret
+}
+
+// Block diagonal matrix with the given blocks and zeroes elsewhere
+func NewBlockDiagPolyMatrix(blocks ...PolyMatrix) PolyMatrix {
+	rows, cols := 0, 0
+	for _, block := range blocks {
+		rows += block.Rows()
+		cols += block.Cols()
+	}
+
+	ret := NewZeroPolyMatrix(rows, cols)
+	r, c := 0, 0
+	for _, block := range blocks {
+		for i, polyVec := range block {
+			copy(ret[r+i][c:], polyVec)
+		}
+		r += block.Rows()
+		c += block.Cols()
+	}
+	return ret
+}
+
+// Kronecker product mat ⊗ other: entry (i, j) of mat becomes the block
+// mat[i][j] * other
+func (mat PolyMatrix) Kronecker(other PolyMatrix) PolyMatrix {
+	ret := make(PolyMatrix, mat.Rows()*other.Rows())
+	for i := range ret {
+		ret[i] = make(vector.PolyVector, mat.Cols()*other.Cols())
+	}
+
+	for i, polyVec := range mat {
+		for j, p := range polyVec {
+			for k, otherVec := range other {
+				for l, o := range otherVec {
+					ret[i*other.Rows()+k][j*other.Cols()+l] = p.Mul(o)
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// Kronecker product mat ⊗ m with an integer matrix, see PolyQMatrix.KroneckerInt
+func (mat PolyMatrix) KroneckerInt(m intmatrix.IntMatrix) PolyMatrix {
+	ret := make(PolyMatrix, mat.Rows()*m.Rows())
+	for i := range ret {
+		ret[i] = make(vector.PolyVector, mat.Cols()*m.Cols())
+	}
+
+	for i, polyVec := range mat {
+		for j, p := range polyVec {
+			for k, row := range m {
+				for l, entry := range row {
+					ret[i*m.Rows()+k][j*m.Cols()+l] = p.ScaledByInt(entry)
+				}
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,172 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPolyQMatrixSplitInvertsConcat(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 3, 2)
+	b := NewRandomPolyQMatrix(nil, 3, 4)
+
+	left, right := a.Concat(b).SplitCols(2)
+	if !left.Equals(a) || !right.Equals(b) {
+		t.Error("SplitCols does not invert Concat")
+	}
+
+	c := NewRandomPolyQMatrix(nil, 1, 2)
+	top, bottom := a.BlockCombine(c).SplitRows(3)
+	if !top.Equals(a) || !bottom.Equals(c) {
+		t.Error("SplitRows does not invert BlockCombine")
+	}
+}
+
+func TestPolyQMatrixSlice(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 4, 5)
+	s := a.Slice(1, 3, 2, 5)
+
+	if s.Rows() != 2 || s.Cols() != 3 {
+		t.Fatalf("Slice has shape %dx%d", s.Rows(), s.Cols())
+	}
+	for i := range s {
+		for j := range s[i] {
+			if !s[i][j].Equals(a[1+i][2+j]) {
+				t.Errorf("Slice entry (%d, %d) differs", i, j)
+			}
+		}
+	}
+
+	// Appending to a row of the view must not overwrite the next column of a
+	s = a.Slice(0, 1, 0, 2)
+	before := poly.PolyQ{Poly: *a[0][2].CopyNew()}
+	_ = append(s[0], poly.NewPolyQ())
+	if !a[0][2].Equals(before) {
+		t.Error("append through a view modified the parent")
+	}
+}
+
+func TestPolyQMatrixPermutations(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 3, 3)
+	perm := []int{2, 0, 1}
+	inverse := []int{1, 2, 0}
+
+	if !a.PermutedRows(perm).PermutedRows(inverse).Equals(a) {
+		t.Error("row permutation is not inverted")
+	}
+	if !a.PermutedCols(perm).PermutedCols(inverse).Equals(a) {
+		t.Error("column permutation is not inverted")
+	}
+
+	// P * A with the permutation matrix P
+	p := NewZeroPolyQMatrix(3, 3)
+	for i, j := range perm {
+		p[i][j] = poly.NewPolyQFromCoeffs(1)
+	}
+	if !p.MatMul(a).Equals(a.PermutedRows(perm)) {
+		t.Error("PermutedRows does not match multiplication by a permutation matrix")
+	}
+	if !a.MatMul(p.Transposed()).Equals(a.PermutedCols(perm)) {
+		t.Error("PermutedCols does not match multiplication by a permutation matrix")
+	}
+}
+
+func TestPermutationRejectsDuplicates(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("PermutedRows accepted a non-permutation")
+		}
+	}()
+	NewRandomPolyQMatrix(nil, 2, 2).PermutedRows([]int{0, 0})
+}
+
+func TestNewBlockDiagPolyQMatrix(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	b := NewRandomPolyQMatrix(nil, 1, 2)
+	d := NewBlockDiagPolyQMatrix(a, b)
+
+	if d.Rows() != 3 || d.Cols() != 5 {
+		t.Fatalf("BlockDiag has shape %dx%d", d.Rows(), d.Cols())
+	}
+
+	topRight := NewZeroPolyQMatrix(2, 2)
+	bottomLeft := NewZeroPolyQMatrix(1, 3)
+	expected := a.Concat(topRight).BlockCombine(bottomLeft.Concat(b))
+	if !d.Equals(expected) {
+		t.Error("BlockDiag differs from the concatenated blocks")
+	}
+
+	if !NewBlockDiagPolyQMatrix(NewIdentityPolyQMatrix(1), NewIdentityPolyQMatrix(2)).Equals(NewIdentityPolyQMatrix(3)) {
+		t.Error("BlockDiag of identities is not the identity")
+	}
+}
+
+func TestPolyQMatrixKroneckerGadget(t *testing.T) {
+	g := intmatrix.IntMatrix{{1, 4, 16}}
+	gadget := NewIdentityPolyQMatrix(2).KroneckerInt(g)
+
+	if gadget.Rows() != 2 || gadget.Cols() != 6 {
+		t.Fatalf("I ⊗ g has shape %dx%d", gadget.Rows(), gadget.Cols())
+	}
+
+	// G * (x_0 digits, x_1 digits) = (x_0, x_1)
+	x := vector.PolyQVector{
+		poly.NewPolyQFromCoeffs(1), poly.NewPolyQFromCoeffs(2), poly.NewPolyQFromCoeffs(3),
+		poly.NewPolyQFromCoeffs(0), poly.NewPolyQFromCoeffs(1), poly.NewPolyQFromCoeffs(0, 1),
+	}
+	expected := vector.PolyQVector{poly.NewPolyQFromCoeffs(1 + 2*4 + 3*16), poly.NewPolyQFromCoeffs(4, 16)}
+	if !gadget.VecMul(x).Equals(expected) {
+		t.Error("gadget matrix does not recompose digits")
+	}
+
+	// KroneckerInt agrees with Kronecker against the lifted integer matrix
+	a := NewRandomPolyQMatrix(nil, 2, 2)
+	m := intmatrix.IntMatrix{{1, 2}, {0, -3}}
+	lifted := PolyQMatrix{
+		{poly.NewPolyQFromCoeffs(1), poly.NewPolyQFromCoeffs(2)},
+		{poly.NewPolyQFromCoeffs(0), poly.NewPolyQFromCoeffs(-3)},
+	}
+	if !a.KroneckerInt(m).Equals(a.Kronecker(lifted)) {
+		t.Error("KroneckerInt differs from Kronecker")
+	}
+}
+
+// (A ⊗ B)(C ⊗ D) = AC ⊗ BD
+func TestPolyMatrixKroneckerMixedProduct(t *testing.T) {
+	a, c := newSmallPolyMatrix(2, 2, 5), newSmallPolyMatrix(2, 1, 5)
+	b, d := newSmallPolyMatrix(1, 2, 5), newSmallPolyMatrix(2, 2, 5)
+
+	if !a.Kronecker(b).MatMul(c.Kronecker(d)).Equals(a.MatMul(c).Kronecker(b.MatMul(d))) {
+		t.Error("mixed-product property does not hold")
+	}
+}
+
+func TestPolyMatrixBlockOps(t *testing.T) {
+	a := newSmallPolyMatrix(2, 3, 10)
+	b := newSmallPolyMatrix(2, 1, 10)
+
+	left, right := a.Concat(b).SplitCols(3)
+	if !left.Equals(a) || !right.Equals(b) {
+		t.Error("SplitCols does not invert Concat")
+	}
+
+	top, bottom := a.BlockCombine(a).SplitRows(2)
+	if !top.Equals(a) || !bottom.Equals(a) {
+		t.Error("SplitRows does not invert BlockCombine")
+	}
+
+	if !a.PermutedCols([]int{1, 2, 0}).Slice(0, 2, 2, 3).Equals(a.Slice(0, 2, 0, 1)) {
+		t.Error("PermutedCols moved the wrong column")
+	}
+
+	d := NewBlockDiagPolyMatrix(a, b)
+	if !d.Slice(0, 2, 0, 3).Equals(a) || !d.Slice(2, 4, 3, 4).Equals(b) || !d.Slice(0, 2, 3, 4).Equals(NewZeroPolyMatrix(2, 1)) {
+		t.Error("BlockDiag has wrong blocks")
+	}
+
+	if !a.KroneckerInt(intmatrix.IntMatrix{{2}}).Equals(a.ScaledByInt(2)) {
+		t.Error("KroneckerInt with a scalar is not scaling")
+	}
+}
@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+// Runs every operation from many goroutines at once and compares the
+// results with a sequential run. Meant for `go test -race`.
+func runConcurrently(t *testing.T, ops map[string]func() any) {
+	expected := make(map[string]any, len(ops))
+	for name, op := range ops {
+		expected[name] = op()
+	}
+
+	var wg sync.WaitGroup
+	for name, op := range ops {
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(name string, op func() any) {
+				defer wg.Done()
+				if result := op(); !reflect.DeepEqual(result, expected[name]) {
+					t.Errorf("%s: concurrent result differs from sequential one", name)
+				}
+			}(name, op)
+		}
+	}
+	wg.Wait()
+}
+
+func copyPolyQMatrix(mat PolyQMatrix) PolyQMatrix {
+	ret := make(PolyQMatrix, mat.Rows())
+	for i, vec := range mat {
+		ret[i] = make(vector.PolyQVector, vec.Length())
+		for j, p := range vec {
+			ret[i][j] = poly.PolyQ{Poly: *p.CopyNew()}
+		}
+	}
+	return ret
+}
+
+func TestPolyQMatrixConcurrentReaders(t *testing.T) {
+	latticehelper.SetParallelism(3)
+	defer latticehelper.SetParallelism(1)
+
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	b := NewRandomPolyQMatrix(nil, 2, 3)
+	v := vector.NewRandomPolyQVector(nil, 3)
+	p := poly.NewRandomPolyQ(nil)
+	aCopy, vCopy := copyPolyQMatrix(a), vector.PolyQVector(copyPolyQMatrix(PolyQMatrix{v})[0])
+	pre := Precompute(a)
+
+	runConcurrently(t, map[string]func() any{
+		"MatMul":        func() any { return a.MatMul(b.Transposed()) },
+		"VecMul":        func() any { return a.VecMul(v) },
+		"LeftVecMul":    func() any { return a.LeftVecMul(a.VecMul(v)) },
+		"Kronecker":     func() any { return a.Kronecker(b) },
+		"Precomputed":   func() any { return pre.MulVecNew(v) },
+		"PrecomputedT":  func() any { return pre.MulVecTransposedNew(a.VecMul(v)) },
+		"Transposed":    func() any { return a.Transposed() },
+		"Add":           func() any { return a.Add(b) },
+		"Sub":           func() any { return a.Sub(b) },
+		"ScaledByPolyQ": func() any { return a.ScaledByPolyQ(p) },
+		"ScaleByInt":    func() any { return a.ScaleByInt(3) },
+		"Concat":        func() any { return a.Concat(b) },
+		"BlockCombine":  func() any { return a.BlockCombine(b) },
+		"HighBits":      func() any { return a.HighBits(1 << 20) },
+		"Power2Round": func() any {
+			r1, r0 := a.Power2Round(13)
+			return []PolyQMatrix{r1, r0}
+		},
+		"InfiniteNorm": func() any { return a.InfiniteNorm() },
+		"OperatorNorm": func() any { return a.OperatorNorm() },
+		"IntMatrix":    func() any { return a.IntMatrix() },
+		"Kernel":       func() any { return a.Kernel() },
+		"Solve":        func() any { x, err := a.Solve(a.VecMul(v)); return []any{x, err} },
+		"NonQ":         func() any { return a.NonQ() },
+		"String":       func() any { return a.String() },
+		"Serialize":    func() any { return a.Serialize() },
+		"WriteTo": func() any {
+			var buf bytes.Buffer
+			a.WriteToWithOptions(&buf, wire.StreamOptions{RowChecksums: true})
+			return buf.Bytes()
+		},
+		"Equals":         func() any { return a.Equals(aCopy) },
+		"VectorEquality": func() any { return v.Equals(vCopy) },
+	})
+
+	if !a.Equals(aCopy) || !v.Equals(vCopy) {
+		t.Error("Read-only operations modified their inputs")
+	}
+}
+
+func TestPolyMatrixConcurrentReaders(t *testing.T) {
+	a := newSmallPolyMatrix(2, 3, 100)
+	b := newSmallPolyMatrix(3, 2, 100)
+	v := newSmallPolyMatrix(1, 3, 100)[0]
+
+	runConcurrently(t, map[string]func() any{
+		"MatMul":       func() any { return a.MatMulParallel(b, 2) },
+		"VecMul":       func() any { return a.VecMul(v) },
+		"Transposed":   func() any { return a.Transposed() },
+		"Add":          func() any { return a.Add(a) },
+		"LowBits":      func() any { return a.LowBits(1 << 10) },
+		"OperatorNorm": func() any { return a.OperatorNorm() },
+		"Q":            func() any { return a.Q() },
+		"String":       func() any { return a.String() },
+	})
+}
@@ -0,0 +1,131 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+// Rows and cols of a rectangular matrix, or an error for ragged rows.
+// An empty matrix is 0x0.
+func checkRectangular(rowLengths func(int) int, rows int) (int, error) {
+	if rows == 0 {
+		return 0, nil
+	}
+	cols := rowLengths(0)
+	for i := 1; i < rows; i++ {
+		if rowLengths(i) != cols {
+			return 0, fmt.Errorf("MarshalBinary: row %d has %d entries, row 0 has %d", i, rowLengths(i), cols)
+		}
+	}
+	return cols, nil
+}
+
+// Only 0x0 may be empty. A header with rows but no columns would pass
+// CheckPayload with an empty payload and still allocate rows slices.
+func checkMatrixDims(rows, cols int, name string) error {
+	if (rows == 0) != (cols == 0) {
+		return fmt.Errorf("%w: %s with dimensions %dx%d", wire.ErrMalformed, name, rows, cols)
+	}
+	return nil
+}
+
+// Versioned encoding with the ring fingerprint, see package wire.
+// Serialize keeps the older format.
+func (mat PolyQMatrix) MarshalBinary() ([]byte, error) {
+	cols, err := checkRectangular(func(i int) int { return mat[i].Length() }, mat.Rows())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMatrixDims(mat.Rows(), cols, "PolyQMatrix"); err != nil {
+		return nil, fmt.Errorf("MarshalBinary: %w", err)
+	}
+
+	b := make([]byte, 0, wire.HeaderSize+mat.Rows()*cols*wire.PolyQSize())
+	b = wire.AppendHeader(b, wire.TagPolyQMatrix, mat.Rows(), cols)
+	for i, polyQVec := range mat {
+		for j, p := range polyQVec {
+			if err := wire.CheckPolyQ(p.Coeffs); err != nil {
+				return nil, fmt.Errorf("MarshalBinary: entry (%d, %d): %w", i, j, err)
+			}
+			b = wire.AppendPolyQ(b, p.Coeffs)
+		}
+	}
+	return b, nil
+}
+
+func (mat *PolyQMatrix) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPolyQMatrix)
+	if err != nil {
+		return err
+	}
+	if err := checkMatrixDims(rows, cols, "PolyQMatrix"); err != nil {
+		return err
+	}
+	if err := wire.CheckPayload(payload, rows, cols, wire.PolyQSize()); err != nil {
+		return err
+	}
+
+	ret := make(PolyQMatrix, rows)
+	for i := range ret {
+		ret[i] = make(vector.PolyQVector, cols)
+		for j := range ret[i] {
+			ret[i][j] = poly.NewPolyQ()
+			if payload, err = wire.ReadPolyQ(payload, ret[i][j].Coeffs); err != nil {
+				return err
+			}
+		}
+	}
+	*mat = ret
+	return nil
+}
+
+func (mat PolyMatrix) MarshalBinary() ([]byte, error) {
+	cols, err := checkRectangular(func(i int) int { return mat[i].Length() }, mat.Rows())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMatrixDims(mat.Rows(), cols, "PolyMatrix"); err != nil {
+		return nil, fmt.Errorf("MarshalBinary: %w", err)
+	}
+
+	b := make([]byte, 0, wire.HeaderSize+mat.Rows()*cols*wire.PolySize())
+	b = wire.AppendHeader(b, wire.TagPolyMatrix, mat.Rows(), cols)
+	for i, polyVec := range mat {
+		for j, p := range polyVec {
+			if p.Length() != wire.PolySize()/8 {
+				return nil, fmt.Errorf("MarshalBinary: entry (%d, %d) has %d coefficients", i, j, p.Length())
+			}
+			b = wire.AppendPoly(b, p)
+		}
+	}
+	return b, nil
+}
+
+func (mat *PolyMatrix) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPolyMatrix)
+	if err != nil {
+		return err
+	}
+	if err := checkMatrixDims(rows, cols, "PolyMatrix"); err != nil {
+		return err
+	}
+	if err := wire.CheckPayload(payload, rows, cols, wire.PolySize()); err != nil {
+		return err
+	}
+
+	ret := make(PolyMatrix, rows)
+	for i := range ret {
+		ret[i] = make(vector.PolyVector, cols)
+		for j := range ret[i] {
+			ret[i][j] = poly.NewPoly()
+			if payload, err = wire.ReadPoly(payload, ret[i][j]); err != nil {
+				return err
+			}
+		}
+	}
+	*mat = ret
+	return nil
+}
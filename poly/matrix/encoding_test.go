@@ -0,0 +1,119 @@
+package matrix
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQMatrixMarshalBinary(t *testing.T) {
+	for _, dims := range [][2]int{{0, 0}, {1, 4}, {3, 2}} {
+		mat := NewRandomPolyQMatrix(nil, dims[0], dims[1])
+
+		b, err := mat.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded PolyQMatrix
+		if err := decoded.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Rows() != dims[0] || (dims[0] > 0 && !decoded.Equals(mat)) {
+			t.Errorf("%dx%d PolyQMatrix: binary round trip failed", dims[0], dims[1])
+		}
+	}
+}
+
+func TestPolyMatrixMarshalBinary(t *testing.T) {
+	mat := NewRandomPolyMatrix(2, 3)
+
+	b, err := mat.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded PolyMatrix
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(mat) {
+		t.Error("PolyMatrix binary round trip failed")
+	}
+
+	var wrongType PolyQMatrix
+	if err := wrongType.UnmarshalBinary(b); !errors.Is(err, wire.ErrType) {
+		t.Errorf("PolyMatrix encoding decoded as PolyQMatrix: %v", err)
+	}
+}
+
+func TestMarshalBinaryRejectsRaggedMatrix(t *testing.T) {
+	mat := PolyQMatrix{vector.NewRandomPolyQVector(nil, 2), vector.NewRandomPolyQVector(nil, 1)}
+	if _, err := mat.MarshalBinary(); err == nil {
+		t.Error("ragged matrix encoded")
+	}
+}
+
+// Rows without columns pass CheckPayload with no payload at all
+func TestUnmarshalBinaryRejectsRowsWithoutCols(t *testing.T) {
+	var q PolyQMatrix
+	if err := q.UnmarshalBinary(wire.AppendHeader(nil, wire.TagPolyQMatrix, 1<<27, 0)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("PolyQMatrix %dx0: %v", 1<<27, err)
+	}
+	var p PolyMatrix
+	if err := p.UnmarshalBinary(wire.AppendHeader(nil, wire.TagPolyMatrix, 1<<32-1, 0)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("PolyMatrix %dx0: %v", 1<<32-1, err)
+	}
+
+	if _, err := (PolyQMatrix{{}, {}}).MarshalBinary(); err == nil {
+		t.Error("2x0 PolyQMatrix encoded")
+	}
+	if _, err := (PolyMatrix{{}}).MarshalBinary(); err == nil {
+		t.Error("1x0 PolyMatrix encoded")
+	}
+}
+
+func FuzzPolyQMatrixUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPolyQMatrix(nil, 2, 1).MarshalBinary()
+	f.Add(b)
+	b, _ = PolyQMatrix{}.MarshalBinary()
+	f.Add(b)
+	f.Add(wire.AppendHeader(nil, wire.TagPolyQMatrix, 1<<27, 0))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var mat PolyQMatrix
+		if mat.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := mat.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded PolyQMatrix does not re-encode to its input")
+		}
+	})
+}
+
+func FuzzPolyMatrixUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPolyMatrix(1, 2).MarshalBinary()
+	f.Add(b)
+	f.Add(wire.AppendHeader(nil, wire.TagPolyMatrix, 1<<27, 0))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var mat PolyMatrix
+		if mat.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := mat.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded PolyMatrix does not re-encode to its input")
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"github.com/isri-pqc/latticehelper/internal/format"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Formats mat with centered coefficients and poly.DefaultFormatOptions,
+// see poly.Format
+func Format[T PolyMatrix | PolyQMatrix](mat T, style poly.Style) string {
+	return FormatWithOptions(mat, style, poly.DefaultFormatOptions)
+}
+
+// Matrices larger than opts.MaxRows x opts.MaxCols are shortened with
+// ellipses before the last row and column
+func FormatWithOptions[T PolyMatrix | PolyQMatrix](mat T, style poly.Style, opts poly.FormatOptions) string {
+	var cols int
+	var entry func(i, j int) string
+	switch m := any(mat).(type) {
+	case PolyMatrix:
+		if len(m) > 0 {
+			cols = m.Cols()
+		}
+		entry = func(i, j int) string { return poly.FormatWithOptions(m[i][j], style, opts) }
+	case PolyQMatrix:
+		if len(m) > 0 {
+			cols = m.Cols()
+		}
+		entry = func(i, j int) string { return poly.FormatWithOptions(m[i][j], style, opts) }
+	}
+	return format.Grid(len(mat), cols, entry, int(style), opts.MaxRows, opts.MaxCols)
+}
@@ -0,0 +1,53 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestFormatMatrix(t *testing.T) {
+	mat := NewPolyMatrixFromCoeffs([][][]int64{{{1}, {0, -1}}, {{2, 3}, {}}})
+
+	cases := map[poly.Style]string{
+		poly.LaTeX: "\\begin{pmatrix}\n" +
+			"  1 & -x \\\\\n" +
+			"  2 + 3x & 0\n" +
+			"\\end{pmatrix}",
+		poly.Markdown: "|  | 0 | 1 |\n" +
+			"| --- | --- | --- |\n" +
+			"| 0 | `1` | `-x` |\n" +
+			"| 1 | `2 + 3x` | `0` |",
+		poly.Compact: "[[1, -x], [2 + 3x, 0]]",
+	}
+	for style, expected := range cases {
+		if s := Format(mat, style); s != expected {
+			t.Errorf("%v: got\n%s\nwant\n%s", style, s, expected)
+		}
+	}
+
+	// Centered coefficients for PolyQ
+	if s := Format(mat.Q(), poly.Compact); s != cases[poly.Compact] {
+		t.Errorf("PolyQMatrix not centered: %s", s)
+	}
+}
+
+func TestFormatMatrixTruncation(t *testing.T) {
+	mat := NewZeroPolyQMatrix(5, 4)
+	opts := poly.FormatOptions{MaxRows: 2, MaxCols: 3}
+
+	expected := "\\begin{pmatrix}\n" +
+		"  0 & 0 & \\cdots & 0 \\\\\n" +
+		"  \\vdots & \\vdots & \\ddots & \\vdots \\\\\n" +
+		"  0 & 0 & \\cdots & 0\n" +
+		"\\end{pmatrix}"
+	if s := FormatWithOptions(mat, poly.LaTeX, opts); s != expected {
+		t.Errorf("truncated LaTeX:\n%s", s)
+	}
+	if s := FormatWithOptions(mat, poly.Compact, opts); s != "[[0, 0, ..., 0], ..., [0, 0, ..., 0]]" {
+		t.Errorf("truncated compact: %s", s)
+	}
+	if s := Format(PolyMatrix{}, poly.Compact); s != "[]" {
+		t.Errorf("empty matrix: %s", s)
+	}
+}
@@ -0,0 +1,269 @@
+package matrix
+
+import (
+	"log"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Matrix over either ring, see vector.Vector. Convert with
+// PolyQMatrix.Generic and NewPolyQMatrixFromGeneric (or the PolyMatrix
+// counterparts).
+type Matrix[T poly.RingElement[T]] []vector.Vector[T]
+
+// newElement is poly.NewPolyFromCoeffs or poly.NewPolyQFromCoeffs
+func NewZeroMatrix[T poly.RingElement[T]](newElement func(...int64) T, rows, cols int) Matrix[T] {
+	mat := make(Matrix[T], rows)
+	for i := range mat {
+		mat[i] = vector.NewZeroVector(newElement, cols)
+	}
+	return mat
+}
+
+func NewIdentityMatrix[T poly.RingElement[T]](newElement func(...int64) T, size int) Matrix[T] {
+	mat := NewZeroMatrix(newElement, size, size)
+	for i := range mat {
+		mat[i][i] = newElement(1)
+	}
+	return mat
+}
+
+func (mat PolyQMatrix) Generic() Matrix[poly.PolyQ] {
+	ret := make(Matrix[poly.PolyQ], mat.Rows())
+	for i, polyQVec := range mat {
+		ret[i] = vector.Vector[poly.PolyQ](polyQVec)
+	}
+	return ret
+}
+
+func NewPolyQMatrixFromGeneric(mat Matrix[poly.PolyQ]) PolyQMatrix {
+	ret := make(PolyQMatrix, mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vector.PolyQVector(vec)
+	}
+	return ret
+}
+
+func (mat PolyMatrix) Generic() Matrix[poly.Poly] {
+	ret := make(Matrix[poly.Poly], mat.Rows())
+	for i, polyVec := range mat {
+		ret[i] = vector.Vector[poly.Poly](polyVec)
+	}
+	return ret
+}
+
+func NewPolyMatrixFromGeneric(mat Matrix[poly.Poly]) PolyMatrix {
+	ret := make(PolyMatrix, mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vector.PolyVector(vec)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) Rows() int {
+	return len(mat)
+}
+
+func (mat Matrix[T]) Cols() int {
+	if len(mat) == 0 {
+		return 0
+	}
+	return len(mat[0])
+}
+
+func (mat Matrix[T]) Listize() []int64 {
+	ret := make([]int64, 0)
+	for _, vec := range mat {
+		ret = append(ret, vec.Listize()...)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) CoeffString() string {
+	vecStrings := make([]string, mat.Rows())
+	for i, vec := range mat {
+		vecStrings[i] = vec.CoeffString()
+	}
+	return "[" + strings.Join(vecStrings, ",") + "]"
+}
+
+func (mat Matrix[T]) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("Matrix{\n")
+	for _, vec := range mat {
+		sb.WriteString("\t" + vec.String() + "\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// Shares the elements with mat
+func (mat Matrix[T]) Transposed() Matrix[T] {
+	ret := make(Matrix[T], mat.Cols())
+	for j := range ret {
+		ret[j] = make(vector.Vector[T], mat.Rows())
+		for i := range mat {
+			ret[j][i] = mat[i][j]
+		}
+	}
+	return ret
+}
+
+// Rows r0 .. r1-1 and cols c0 .. c1-1, sharing the elements with mat
+func (mat Matrix[T]) Slice(r0, r1, c0, c1 int) Matrix[T] {
+	checkSliceBounds(mat.Rows(), mat.Cols(), r0, r1, c0, c1)
+
+	ret := make(Matrix[T], r1-r0)
+	for i := range ret {
+		ret[i] = mat[r0+i][c0:c1:c1]
+	}
+	return ret
+}
+
+func (mat Matrix[T]) Concat(inputMatrix Matrix[T]) Matrix[T] {
+	if mat.Rows() != inputMatrix.Rows() {
+		log.Panic("Concat: rows of matrices are not equal")
+	}
+
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.Concat(inputMatrix[i])
+	}
+	return ret
+}
+
+func (mat Matrix[T]) BlockCombine(inputMatrix Matrix[T]) Matrix[T] {
+	if mat.Cols() != inputMatrix.Cols() {
+		log.Panic("BlockCombine: cols of matrices are not equal")
+	}
+
+	ret := make(Matrix[T], 0, mat.Rows()+inputMatrix.Rows())
+	ret = append(ret, mat...)
+	return append(ret, inputMatrix...)
+}
+
+func (mat Matrix[T]) Add(inputMatrix Matrix[T]) Matrix[T] {
+	if mat.Rows() != inputMatrix.Rows() {
+		log.Panic("Add: rows of matrices are not equal")
+	}
+
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.Add(inputMatrix[i])
+	}
+	return ret
+}
+
+func (mat Matrix[T]) Sub(inputMatrix Matrix[T]) Matrix[T] {
+	if mat.Rows() != inputMatrix.Rows() {
+		log.Panic("Sub: rows of matrices are not equal")
+	}
+
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.Sub(inputMatrix[i])
+	}
+	return ret
+}
+
+func (mat Matrix[T]) ScaledBy(input T) Matrix[T] {
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.ScaledBy(input)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) ScaledByInt(input int64) Matrix[T] {
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.ScaledByInt(input)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) MatMul(inputMatrix Matrix[T]) Matrix[T] {
+	if mat.Cols() != inputMatrix.Rows() {
+		log.Panic("MatMul: Number of cols in first mat is not equal to number of rows in second mat")
+	}
+
+	transposed := inputMatrix.Transposed()
+
+	ret := make(Matrix[T], mat.Rows())
+	parallelFor(mat.Rows(), latticehelper.Parallelism(), func(_, i int) {
+		ret[i] = make(vector.Vector[T], transposed.Rows())
+		for j, col := range transposed {
+			ret[i][j] = mat[i].DotProduct(col)
+		}
+	})
+	return ret
+}
+
+func (mat Matrix[T]) VecMul(inputVector vector.Vector[T]) vector.Vector[T] {
+	if inputVector.Length() != mat.Cols() {
+		log.Panic("VecMul: vectors don't have the same length")
+	}
+
+	ret := make(vector.Vector[T], mat.Rows())
+	parallelFor(mat.Rows(), latticehelper.Parallelism(), func(_, i int) {
+		ret[i] = mat[i].DotProduct(inputVector)
+	})
+	return ret
+}
+
+// Row vector times matrix, v^T * mat
+func (mat Matrix[T]) LeftVecMul(inputVector vector.Vector[T]) vector.Vector[T] {
+	if inputVector.Length() != mat.Rows() {
+		log.Panic("LeftVecMul: vector length is not equal to number of rows")
+	}
+	return mat.Transposed().VecMul(inputVector)
+}
+
+func (mat Matrix[T]) HighBits(alpha int64) Matrix[T] {
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.HighBits(alpha)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) LowBits(alpha int64) Matrix[T] {
+	ret := make(Matrix[T], mat.Rows())
+	for i, vec := range mat {
+		ret[i] = vec.LowBits(alpha)
+	}
+	return ret
+}
+
+func (mat Matrix[T]) InfiniteNorm() int64 {
+	ret := int64(0)
+	for _, vec := range mat {
+		ret = max(ret, vec.InfiniteNorm())
+	}
+	return ret
+}
+
+func (mat Matrix[T]) CheckNormBound(bound int64) bool {
+	for _, vec := range mat {
+		if vec.CheckNormBound(bound) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mat Matrix[T]) Equals(other Matrix[T]) bool {
+	if mat.Rows() != other.Rows() {
+		return false
+	}
+	for i := range mat {
+		if !mat[i].Equals(other[i]) {
+			return false
+		}
+	}
+	return true
+}
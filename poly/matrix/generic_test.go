@@ -0,0 +1,77 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Written once, runs over both rings: A*z - c*t == w and ||z|| < bound
+func verifyRelation[T poly.RingElement[T]](a Matrix[T], z vector.Vector[T], c T, t, w vector.Vector[T], bound int64) bool {
+	return a.VecMul(z).Sub(t.ScaledBy(c)).Equals(w) && !z.CheckNormBound(bound)
+}
+
+func TestGenericVerifierOverBothRings(t *testing.T) {
+	a := newSmallPolyMatrix(2, 3, 10)
+	z := vector.PolyVector{poly.NewPolyFromCoeffs(1, -1), poly.NewPolyFromCoeffs(2), poly.NewPolyFromCoeffs(0, 0, 3)}
+	c := poly.NewPolyFromCoeffs(0, 1)
+	tVec := vector.PolyVector{poly.NewPolyFromCoeffs(5), poly.NewPolyFromCoeffs(-7, 2)}
+	w := a.VecMul(z).Sub(tVec.ScaledByPoly(c))
+
+	if !verifyRelation(a.Generic(), vector.Vector[poly.Poly](z), c, vector.Vector[poly.Poly](tVec), vector.Vector[poly.Poly](w), 4) {
+		t.Error("relation over Z[X]/(X^N + 1) rejected")
+	}
+	if !verifyRelation(a.Q().Generic(), vector.Vector[poly.PolyQ](z.Q()), c.Q(), vector.Vector[poly.PolyQ](tVec.Q()), vector.Vector[poly.PolyQ](w.Q()), 4) {
+		t.Error("relation over Rq rejected")
+	}
+	if verifyRelation(a.Q().Generic(), vector.Vector[poly.PolyQ](z.Q()), c.Q(), vector.Vector[poly.PolyQ](tVec.Q()), vector.Vector[poly.PolyQ](w.Q()), 3) {
+		t.Error("norm bound not enforced")
+	}
+}
+
+func TestGenericMatrixMatchesPolyQMatrix(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	b := NewRandomPolyQMatrix(nil, 3, 2)
+	v := vector.NewRandomPolyQVector(nil, 3)
+	u := vector.NewRandomPolyQVector(nil, 2)
+	ga := a.Generic()
+
+	if !NewPolyQMatrixFromGeneric(ga.MatMul(b.Generic())).Equals(a.MatMul(b)) {
+		t.Error("MatMul differs")
+	}
+	if !vector.PolyQVector(ga.VecMul(vector.Vector[poly.PolyQ](v))).Equals(a.VecMul(v)) {
+		t.Error("VecMul differs")
+	}
+	if !vector.PolyQVector(ga.LeftVecMul(vector.Vector[poly.PolyQ](u))).Equals(a.LeftVecMul(u)) {
+		t.Error("LeftVecMul differs")
+	}
+	if !NewPolyQMatrixFromGeneric(ga.Transposed()).Equals(a.Transposed()) {
+		t.Error("Transposed differs")
+	}
+	if !NewPolyQMatrixFromGeneric(ga.HighBits(256)).Equals(a.HighBits(256)) || ga.InfiniteNorm() != a.InfiniteNorm() {
+		t.Error("HighBits or InfiniteNorm differs")
+	}
+	if !NewPolyQMatrixFromGeneric(NewIdentityMatrix(poly.NewPolyQFromCoeffs, 3)).Equals(NewIdentityPolyQMatrix(3)) {
+		t.Error("identity differs")
+	}
+	if ga.CoeffString() != a.CoeffString() {
+		t.Error("CoeffString differs")
+	}
+}
+
+func TestGenericMatrixMatchesPolyMatrix(t *testing.T) {
+	a := newSmallPolyMatrix(2, 2, 100)
+	b := newSmallPolyMatrix(2, 3, 100)
+	ga := a.Generic()
+
+	if !NewPolyMatrixFromGeneric(ga.MatMul(b.Generic())).Equals(a.MatMul(b)) {
+		t.Error("MatMul differs")
+	}
+	if !NewPolyMatrixFromGeneric(ga.Concat(b.Generic()).Slice(0, 2, 2, 5)).Equals(b) {
+		t.Error("Concat or Slice differs")
+	}
+	if !NewPolyMatrixFromGeneric(ga.LowBits(256)).Equals(a.LowBits(256)) || ga.InfiniteNorm() != a.InfiniteNorm() {
+		t.Error("LowBits or InfiniteNorm differs")
+	}
+}
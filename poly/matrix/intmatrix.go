@@ -0,0 +1,56 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Flat (SIS/LWE) view of the matrix, see BigToeplitz
+func (mat PolyQMatrix) IntMatrix() intmatrix.IntMatrix {
+	return intmatrix.IntMatrix(BigToeplitz(mat, mat.Rows(), mat.Cols()))
+}
+
+// Inverse of PolyQMatrix.IntMatrix. Every N x N block has to be the
+// negacyclic matrix of a polynomial, otherwise an error is returned.
+func NewPolyQMatrixFromIntMatrix(mat intmatrix.IntMatrix) (PolyQMatrix, error) {
+	n := latticehelper.MainRing.N()
+	q := latticehelper.MainRing.Modulus().Int64()
+
+	if mat.Rows()%n != 0 || mat.Cols()%n != 0 {
+		return nil, fmt.Errorf("NewPolyQMatrixFromIntMatrix: dimensions %dx%d are not multiples of %d", mat.Rows(), mat.Cols(), n)
+	}
+
+	rows, cols := mat.Rows()/n, mat.Cols()/n
+	ret := make(PolyQMatrix, rows)
+
+	for i := 0; i < rows; i++ {
+		ret[i] = make(vector.PolyQVector, cols)
+		for j := 0; j < cols; j++ {
+			coeffs := make([]int64, n)
+			for k := 0; k < n; k++ {
+				coeffs[k] = mat[i*n+k][j*n]
+			}
+
+			for r := 0; r < n; r++ {
+				for c := 0; c < n; c++ {
+					expected := coeffs[latticehelper.PositiveMod(int64(r-c), int64(n))]
+					if c > r {
+						expected = -expected
+					}
+					if latticehelper.PositiveMod(mat[i*n+r][j*n+c]-expected, q) != 0 {
+						return nil, errors.New("NewPolyQMatrixFromIntMatrix: block is not a negacyclic matrix")
+					}
+				}
+			}
+
+			ret[i][j] = poly.NewPolyQFromCoeffs(coeffs...)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,47 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPolyQMatrixRoundTrip(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+
+	flat := a.IntMatrix()
+	back, err := NewPolyQMatrixFromIntMatrix(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !back.Equals(a) {
+		t.Error("PolyQMatrix round trip failed")
+	}
+}
+
+func TestPolyQMatrixRejectsUnstructured(t *testing.T) {
+	flat := NewRandomPolyQMatrix(nil, 1, 1).IntMatrix()
+	flat[1][1]++
+
+	if _, err := NewPolyQMatrixFromIntMatrix(flat); err == nil {
+		t.Error("Expected error for non-negacyclic block")
+	}
+}
+
+func TestVecMulModMatchesModuleVecMul(t *testing.T) {
+	q := latticehelper.MainRing.Modulus().Int64()
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	v := vector.NewRandomPolyQVector(nil, 3)
+
+	flat := a.IntMatrix().VecMulMod(v.Listize(), q)
+	result, err := vector.NewPolyQVectorFromList(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.Equals(a.VecMul(v)) {
+		t.Error("Flat and module products differ")
+	}
+}
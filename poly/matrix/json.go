@@ -0,0 +1,121 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func (mat PolyQMatrix) MarshalJSON() ([]byte, error) {
+	return mat.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+func (mat PolyQMatrix) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	coeffs := make([][][]int64, mat.Rows())
+	for i, polyQVec := range mat {
+		coeffs[i] = make([][]int64, polyQVec.Length())
+		for j, p := range polyQVec {
+			if opts.Centered {
+				coeffs[i][j] = p.NonQ().WithCenteredModulo()
+			} else {
+				coeffs[i][j] = p.Listize()
+			}
+		}
+	}
+	return wire.MarshalJSON(wire.TagPolyQMatrix, coeffs, opts)
+}
+
+// Accepts the plain array and the metadata form, see poly.PolyQ.UnmarshalJSON
+func (mat *PolyQMatrix) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPolyQMatrix)
+	if err != nil {
+		return err
+	}
+	return mat.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (mat PolyQMatrix) MarshalText() ([]byte, error) {
+	return []byte(mat.CoeffString()), nil
+}
+
+// Parses the CoeffString form, rows must have equal lengths
+func (mat *PolyQMatrix) UnmarshalText(text []byte) error {
+	rows, err := splitJSONRows(text)
+	if err != nil {
+		return err
+	}
+
+	ret := make(PolyQMatrix, len(rows))
+	for i, row := range rows {
+		if err := ret[i].UnmarshalText(row); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		if ret[i].Length() != ret[0].Length() {
+			return fmt.Errorf("%w: row %d has %d entries, row 0 has %d", wire.ErrMalformed, i, ret[i].Length(), ret[0].Length())
+		}
+	}
+	*mat = ret
+	return nil
+}
+
+func (mat PolyMatrix) MarshalJSON() ([]byte, error) {
+	return mat.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+// Poly coefficients are plain integers, opts.Centered does not apply
+func (mat PolyMatrix) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	coeffs := make([][][]int64, mat.Rows())
+	for i, polyVec := range mat {
+		coeffs[i] = make([][]int64, polyVec.Length())
+		for j, p := range polyVec {
+			coeffs[i][j] = p
+		}
+	}
+	return wire.MarshalJSON(wire.TagPolyMatrix, coeffs, opts)
+}
+
+func (mat *PolyMatrix) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPolyMatrix)
+	if err != nil {
+		return err
+	}
+	return mat.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (mat PolyMatrix) MarshalText() ([]byte, error) {
+	return []byte(mat.CoeffString()), nil
+}
+
+// Parses the CoeffString form, rows must have equal lengths
+func (mat *PolyMatrix) UnmarshalText(text []byte) error {
+	rows, err := splitJSONRows(text)
+	if err != nil {
+		return err
+	}
+
+	ret := make(PolyMatrix, len(rows))
+	for i, row := range rows {
+		if err := ret[i].UnmarshalText(row); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		if ret[i].Length() != ret[0].Length() {
+			return fmt.Errorf("%w: row %d has %d entries, row 0 has %d", wire.ErrMalformed, i, ret[i].Length(), ret[0].Length())
+		}
+	}
+	*mat = ret
+	return nil
+}
+
+func splitJSONRows(text []byte) ([]json.RawMessage, error) {
+	var rows []json.RawMessage
+	if err := json.Unmarshal(text, &rows); err != nil {
+		return nil, fmt.Errorf("%w: %v", wire.ErrMalformed, err)
+	}
+	if rows == nil {
+		return nil, fmt.Errorf("%w: expected an array", wire.ErrMalformed)
+	}
+	return rows, nil
+}
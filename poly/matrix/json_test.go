@@ -0,0 +1,53 @@
+package matrix
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQMatrixJSONRoundTrip(t *testing.T) {
+	mat := NewRandomPolyQMatrix(nil, 2, 3)
+
+	for _, opts := range []wire.JSONOptions{{}, {Centered: true}, {Metadata: true}} {
+		b, err := mat.MarshalJSONWithOptions(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded PolyQMatrix
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equals(mat) {
+			t.Errorf("%+v: JSON round trip failed", opts)
+		}
+	}
+
+	var decoded PolyQMatrix
+	if err := decoded.UnmarshalText([]byte(mat.CoeffString())); err != nil || !decoded.Equals(mat) {
+		t.Errorf("CoeffString not parsed back: %v", err)
+	}
+	if err := decoded.UnmarshalText([]byte(`[[[1],[2]],[[3]]]`)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("ragged matrix accepted: %v", err)
+	}
+}
+
+func TestPolyMatrixJSONRoundTrip(t *testing.T) {
+	mat := NewRandomPolyMatrix(3, 2)
+
+	b, err := mat.MarshalJSONWithOptions(wire.JSONOptions{Metadata: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded PolyMatrix
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(mat) {
+		t.Error("JSON round trip failed")
+	}
+}
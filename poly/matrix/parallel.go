@@ -0,0 +1,39 @@
+package matrix
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Calls f(worker, i) for every i in [0, count) using up to workers
+// goroutines. Every index is handled exactly once and worker identifies
+// the goroutine, so f may use per-worker buffers indexed by it.
+func parallelFor(count, workers int, f func(worker, i int)) {
+	workers = max(1, min(workers, count))
+
+	if workers == 1 {
+		for i := 0; i < count; i++ {
+			f(0, i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	var next atomic.Int64
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				current := int(next.Add(1) - 1)
+				if current >= count {
+					return
+				}
+				f(worker, current)
+			}
+		}(w)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,82 @@
+package matrix
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPolyQMatrixParallelMatchesSequential(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 7, 5)
+	b := NewRandomPolyQMatrix(nil, 5, 3)
+	v := vector.NewRandomPolyQVector(nil, 5)
+
+	expectedMat := a.MatMulParallel(b, 1)
+	expectedVec := a.VecMulParallel(v, 1)
+
+	// The flat view is an independent reference for the product
+	flat := a.IntMatrix().VecMulMod(v.Listize(), latticehelper.MainRing.Modulus().Int64())
+	reference, err := vector.NewPolyQVectorFromList(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expectedVec.Equals(reference) {
+		t.Fatal("VecMul differs from the flat product")
+	}
+
+	for _, workers := range []int{2, 3, 8, 64} {
+		if !a.MatMulParallel(b, workers).Equals(expectedMat) {
+			t.Errorf("MatMulParallel with %d workers differs from sequential", workers)
+		}
+		if !a.VecMulParallel(v, workers).Equals(expectedVec) {
+			t.Errorf("VecMulParallel with %d workers differs from sequential", workers)
+		}
+	}
+}
+
+func TestPolyMatrixParallelMatchesSequential(t *testing.T) {
+	a := newSmallPolyMatrix(6, 4, 100)
+	b := newSmallPolyMatrix(4, 2, 100)
+	v := newSmallPolyMatrix(1, 4, 100)[0]
+
+	expectedMat := a.MatMulParallel(b, 1)
+	expectedVec := a.VecMulParallel(v, 1)
+
+	for _, workers := range []int{2, 5, 16} {
+		if !a.MatMulParallel(b, workers).Equals(expectedMat) {
+			t.Errorf("MatMulParallel with %d workers differs from sequential", workers)
+		}
+		if !a.VecMulParallel(v, workers).Equals(expectedVec) {
+			t.Errorf("VecMulParallel with %d workers differs from sequential", workers)
+		}
+	}
+}
+
+func TestSetParallelismConcurrentCallers(t *testing.T) {
+	latticehelper.SetParallelism(4)
+	defer latticehelper.SetParallelism(1)
+
+	a := NewRandomPolyQMatrix(nil, 4, 4)
+	v := vector.NewRandomPolyQVector(nil, 4)
+	expected := a.VecMulParallel(v, 1)
+
+	results := make([]vector.PolyQVector, 8)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = a.VecMul(v)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		if !result.Equals(expected) {
+			t.Error("Concurrent parallel product differs from sequential")
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/internal/parse"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Accepts the String form, one "PolyQVector{...}" per row inside
+// "PolyQMatrix{...}", and the CoeffString form. Rows must have the same length.
+func ParsePolyQMatrix(s string) (PolyQMatrix, error) {
+	rows, err := parseRows(s, "PolyQMatrix")
+	if err != nil {
+		return nil, fmt.Errorf("ParsePolyQMatrix: %w", err)
+	}
+
+	ret := make(PolyQMatrix, len(rows))
+	for i, row := range rows {
+		if ret[i], err = vector.ParsePolyQVector(row); err != nil {
+			return nil, fmt.Errorf("ParsePolyQMatrix: row %d: %w", i, err)
+		}
+		if ret[i].Length() != ret[0].Length() {
+			return nil, fmt.Errorf("ParsePolyQMatrix: %w: row %d has %d entries, expected %d", poly.ErrSyntax, i, ret[i].Length(), ret[0].Length())
+		}
+	}
+	return ret, nil
+}
+
+// Accepts the String form, one "PolyVector{...}" per row inside
+// "PolyMatrix{...}", and the CoeffString form. Rows must have the same length.
+func ParsePolyMatrix(s string) (PolyMatrix, error) {
+	rows, err := parseRows(s, "PolyMatrix")
+	if err != nil {
+		return nil, fmt.Errorf("ParsePolyMatrix: %w", err)
+	}
+
+	ret := make(PolyMatrix, len(rows))
+	for i, row := range rows {
+		if ret[i], err = vector.ParsePolyVector(row); err != nil {
+			return nil, fmt.Errorf("ParsePolyMatrix: row %d: %w", i, err)
+		}
+		if ret[i].Length() != ret[0].Length() {
+			return nil, fmt.Errorf("ParsePolyMatrix: %w: row %d has %d entries, expected %d", poly.ErrSyntax, i, ret[i].Length(), ret[0].Length())
+		}
+	}
+	return ret, nil
+}
+
+func parseRows(s, name string) ([]string, error) {
+	inner, err := parse.Unwrap(s, name, "Matrix")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+	}
+	rows, err := parse.Groups(inner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,40 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestParsePolyQMatrixRoundTrip(t *testing.T) {
+	mat := NewRandomPolyQMatrix(nil, 2, 3)
+	for _, s := range []string{mat.String(), mat.CoeffString(), mat.Generic().String()} {
+		parsed, err := ParsePolyQMatrix(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(mat) {
+			t.Errorf("round trip of %.40q... failed", s)
+		}
+	}
+}
+
+func TestParsePolyMatrix(t *testing.T) {
+	mat := NewPolyMatrixFromCoeffs([][][]int64{{{1, -2}, {0}}, {{5}, {0, 0, -3}}})
+	for _, s := range []string{mat.String(), mat.CoeffString(), "[[1 - 2x, 0], [5, -3x^2]]"} {
+		parsed, err := ParsePolyMatrix(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(mat) {
+			t.Errorf("%q parsed as %v", s, parsed)
+		}
+	}
+
+	for _, s := range []string{"[[1, 2], [3]]", "PolyMatrix{PolyVector{1} x}", "PolyQMatrix{}", "[[1], [2]"} {
+		if _, err := ParsePolyMatrix(s); !errors.Is(err, poly.ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", s, err)
+		}
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"strings"
 
 	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/internal/compress"
 	"github.com/isri-pqc/latticehelper/poly"
 	"github.com/isri-pqc/latticehelper/poly/vector"
-	"github.com/raszia/gotiny"
 	"github.com/tuneinsight/lattigo/v5/ring"
 )
 
@@ -25,7 +25,7 @@ func (mat PolyQMatrix) Serialize() []byte {
 	if err != nil {
 		panic(err)
 	}
-	_, err = buf.Write(gotiny.MarshalCompress(&mat))
+	_, err = buf.Write(compress.Marshal(mat.ringPolys()))
 	if err != nil {
 		panic(err)
 	}
@@ -38,14 +38,33 @@ func DeserializePolyQMatrix(data []byte) PolyQMatrix {
 	_ = binary.Read(bytes.NewReader(data[2:4]), binary.LittleEndian, &cols)
 
 	p := NewZeroPolyQMatrix(int(rows), int(cols))
-	n := gotiny.UnmarshalCompress(data[4:], &p)
+	polys := p.ringPolys()
+	n := compress.Unmarshal(data[4:], polys)
 	if n == 0 {
 		panic("failed to deserialize PolyQVector")
 	}
 
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = poly.PolyQ{Poly: (*polys)[i][j]}
+		}
+	}
 	return p
 }
 
+// Serialize encodes the lattigo polynomials directly, so its format does
+// not depend on PolyQ.MarshalBinary
+func (mat PolyQMatrix) ringPolys() *[][]ring.Poly {
+	polys := make([][]ring.Poly, mat.Rows())
+	for i, polyQVec := range mat {
+		polys[i] = make([]ring.Poly, polyQVec.Length())
+		for j, p := range polyQVec {
+			polys[i][j] = p.Poly
+		}
+	}
+	return &polys
+}
+
 func NewPolyQMatrixFromCoeffs(coeffMat [][][]int64) PolyQMatrix {
 	newMatrix := make(PolyQMatrix, len(coeffMat))
 	for i := range coeffMat {
@@ -184,6 +203,23 @@ func (mat PolyQMatrix) HighBits(alpha int64) PolyQMatrix {
 	return newVec
 }
 
+func (mat PolyQMatrix) LowBits(alpha int64) PolyQMatrix {
+	newVec := make(PolyQMatrix, mat.Rows())
+	for i := 0; i < mat.Rows(); i++ {
+		newVec[i] = mat[i].LowBits(alpha)
+	}
+	return newVec
+}
+
+func (mat PolyQMatrix) CheckNormBound(bound int64) bool {
+	for _, vec := range mat {
+		if vec.CheckNormBound(bound) {
+			return true
+		}
+	}
+	return false
+}
+
 func (mat PolyQMatrix) ScaledByPolyQ(inputPoly poly.PolyQ) PolyQMatrix {
 	result := make(PolyQMatrix, mat.Rows())
 
@@ -255,6 +291,11 @@ func (mat PolyQMatrix) BlockCombine(inputPolyQMatrix PolyQMatrix) PolyQMatrix {
 }
 
 func (mat PolyQMatrix) MatMul(inputPolyQMatrix PolyQMatrix) PolyQMatrix {
+	return mat.MatMulParallel(inputPolyQMatrix, latticehelper.Parallelism())
+}
+
+// Same as MatMul, rows of the result are computed by up to workers goroutines
+func (mat PolyQMatrix) MatMulParallel(inputPolyQMatrix PolyQMatrix, workers int) PolyQMatrix {
 	if mat.Cols() != inputPolyQMatrix.Rows() {
 		log.Panic("MatMul: Number of cols in first mat is not equal to number of rows in second mat")
 	}
@@ -265,22 +306,33 @@ func (mat PolyQMatrix) MatMul(inputPolyQMatrix PolyQMatrix) PolyQMatrix {
 	newMat := make(PolyQMatrix, rows)
 	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
 
-	for i := 0; i < rows; i++ {
+	inputNTT := inputPolyQMatrix.nttSlots()
+
+	// One row of mat in NTT form per worker
+	workers = max(1, min(workers, rows))
+	buffers := make([][]ring.Poly, workers)
+	for w := range buffers {
+		buffers[w] = make([]ring.Poly, cols)
+		for k := range buffers[w] {
+			buffers[w][k] = r.NewPoly()
+		}
+	}
+
+	parallelFor(rows, workers, func(worker, i int) {
+		matNTT := buffers[worker]
+		for k := 0; k < cols; k++ {
+			r.NTT(mat[i][k].Poly, matNTT[k])
+		}
+
 		currentVec := make(vector.PolyQVector, otherCols)
 
 		for j := 0; j < otherCols; j++ {
 			currentPoly := poly.NewPolyQ()
 
 			for k := 0; k < cols; k++ {
-				matNTT := r.NewPoly()
-				inputNTT := r.NewPoly()
-
-				r.NTT(mat[i][k].Poly, matNTT)
-				r.NTT(inputPolyQMatrix[k][j].Poly, inputNTT)
-
 				r.MulCoeffsBarrettThenAdd(
-					matNTT,
-					inputNTT,
+					matNTT[k],
+					inputNTT[k][j],
 					currentPoly.Poly)
 			}
 
@@ -289,34 +341,48 @@ func (mat PolyQMatrix) MatMul(inputPolyQMatrix PolyQMatrix) PolyQMatrix {
 			currentVec[j] = currentPoly
 		}
 		newMat[i] = currentVec
-	}
+	})
 
 	return newMat
 }
 
 func (mat PolyQMatrix) VecMul(inputPolyQVector vector.PolyQVector) vector.PolyQVector {
+	return mat.VecMulParallel(inputPolyQVector, latticehelper.Parallelism())
+}
+
+// Same as VecMul, entries of the result are computed by up to workers goroutines
+func (mat PolyQMatrix) VecMulParallel(inputPolyQVector vector.PolyQVector, workers int) vector.PolyQVector {
 	if inputPolyQVector.Length() != mat.Cols() {
 		log.Panic("VecMul: vectors don't have the same length")
 	}
 	newVec := make(vector.PolyQVector, mat.Rows())
 
 	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
-	for i := 0; i < mat.Rows(); i++ {
+
+	inputNTT := make([]ring.Poly, inputPolyQVector.Length())
+	for j, p := range inputPolyQVector {
+		inputNTT[j] = r.NewPoly()
+		r.NTT(p.Poly, inputNTT[j])
+	}
+
+	workers = max(1, min(workers, mat.Rows()))
+	buffers := make([]ring.Poly, workers)
+	for w := range buffers {
+		buffers[w] = r.NewPoly()
+	}
+
+	parallelFor(mat.Rows(), workers, func(worker, i int) {
+		matNTT := buffers[worker]
 		currentPoly := poly.NewPolyQ()
 
 		for j := 0; j < inputPolyQVector.Length(); j++ {
-			matNTT := r.NewPoly()
-			inputNTT := r.NewPoly()
-
-			r.NTT(inputPolyQVector[j].Poly, inputNTT)
 			r.NTT(mat[i][j].Poly, matNTT)
-
-			r.MulCoeffsBarrettThenAdd(inputNTT, matNTT, currentPoly.Poly)
+			r.MulCoeffsBarrettThenAdd(inputNTT[j], matNTT, currentPoly.Poly)
 		}
 		r.INTT(currentPoly.Poly, currentPoly.Poly)
 
 		newVec[i] = currentPoly
-	}
+	})
 
 	return newVec
 }
@@ -5,23 +5,39 @@ import (
 	"strings"
 
 	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/internal/compress"
 	"github.com/isri-pqc/latticehelper/poly"
 	"github.com/isri-pqc/latticehelper/poly/vector"
-	"github.com/raszia/gotiny"
 )
 
 type PolyMatrix []vector.PolyVector
 
+// Plain [][][]int64 so the encoding does not go through Poly.MarshalBinary
 func (mat PolyMatrix) Serialize() []byte {
-	return gotiny.MarshalCompress(&mat)
+	raw := make([][][]int64, mat.Rows())
+	for i, polyVec := range mat {
+		raw[i] = make([][]int64, polyVec.Length())
+		for j, p := range polyVec {
+			raw[i][j] = p
+		}
+	}
+	return compress.Marshal(&raw)
 }
 
 func DeserializePolyMatrix(data []byte) PolyMatrix {
-	var mat PolyMatrix
-	n := gotiny.UnmarshalCompress(data, &mat)
+	var raw [][][]int64
+	n := compress.Unmarshal(data, &raw)
 	if n == 0 {
 		panic("failed to deserialize")
 	}
+
+	mat := make(PolyMatrix, len(raw))
+	for i, row := range raw {
+		mat[i] = make(vector.PolyVector, len(row))
+		for j, coeffs := range row {
+			mat[i][j] = poly.Poly(coeffs)
+		}
+	}
 	return mat
 }
 
@@ -124,6 +140,26 @@ func (mat PolyMatrix) LowBits(alpha int64) PolyMatrix {
 	return newVec
 }
 
+func (mat PolyMatrix) HighBits(alpha int64) PolyMatrix {
+	newVec := make(PolyMatrix, mat.Rows())
+	for i := 0; i < mat.Rows(); i++ {
+		newVec[i] = mat[i].HighBits(alpha)
+	}
+	return newVec
+}
+
+func (mat PolyMatrix) InfiniteNorm() int64 {
+	max := int64(0)
+	for _, polyVec := range mat {
+		maxVec := polyVec.InfiniteNorm()
+		if maxVec > max {
+			max = maxVec
+		}
+	}
+
+	return max
+}
+
 func (mat PolyMatrix) Transposed() PolyMatrix {
 	cols := mat.Cols()
 	rows := mat.Rows()
@@ -208,6 +244,11 @@ func (mat PolyMatrix) BlockCombine(inputPolyMatrix PolyMatrix) PolyMatrix {
 }
 
 func (mat PolyMatrix) MatMul(inputPolyMatrix PolyMatrix) PolyMatrix {
+	return mat.MatMulParallel(inputPolyMatrix, latticehelper.Parallelism())
+}
+
+// Same as MatMul, rows of the result are computed by up to workers goroutines
+func (mat PolyMatrix) MatMulParallel(inputPolyMatrix PolyMatrix, workers int) PolyMatrix {
 	if mat.Cols() != inputPolyMatrix.Rows() {
 		log.Panic("MatMul: Number of cols in first mat is not equal to number of rows in second mat")
 	}
@@ -217,7 +258,7 @@ func (mat PolyMatrix) MatMul(inputPolyMatrix PolyMatrix) PolyMatrix {
 
 	newMat := make(PolyMatrix, rows)
 
-	for i := 0; i < rows; i++ {
+	parallelFor(rows, workers, func(_, i int) {
 		currentVec := make(vector.PolyVector, otherCols)
 
 		for j := 0; j < otherCols; j++ {
@@ -230,19 +271,24 @@ func (mat PolyMatrix) MatMul(inputPolyMatrix PolyMatrix) PolyMatrix {
 			currentVec[j] = currentPoly
 		}
 		newMat[i] = currentVec
-	}
+	})
 
 	return newMat
 }
 
 func (mat PolyMatrix) VecMul(inputPolyVector vector.PolyVector) vector.PolyVector {
+	return mat.VecMulParallel(inputPolyVector, latticehelper.Parallelism())
+}
+
+// Same as VecMul, entries of the result are computed by up to workers goroutines
+func (mat PolyMatrix) VecMulParallel(inputPolyVector vector.PolyVector, workers int) vector.PolyVector {
 	if inputPolyVector.Length() != mat.Cols() {
 		log.Panic("VecMul: vectors don't have the same length")
 	}
 
 	ret := make(vector.PolyVector, mat.Rows())
 
-	for i := 0; i < len(ret); i++ {
+	parallelFor(len(ret), workers, func(_, i int) {
 		currentPoly := make(poly.Poly, mat[0][0].Length())
 
 		for j := 0; j < inputPolyVector.Length(); j++ {
@@ -250,7 +296,7 @@ func (mat PolyMatrix) VecMul(inputPolyVector vector.PolyVector) vector.PolyVecto
 		}
 
 		ret[i] = currentPoly
-	}
+	})
 	return ret
 }
 
@@ -0,0 +1,112 @@
+package matrix
+
+import (
+	"log"
+	"sync"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// Matrix kept in NTT and Montgomery form, for multiplying the same public
+// matrix by many vectors. Safe for concurrent use; scratch space for the
+// input vector is taken from a pool, so after warm-up MulVec and
+// MulVecTransposed do not allocate.
+type PrecomputedPolyQMatrix struct {
+	r          *ring.Ring
+	rows, cols int
+	ntt        [][]ring.Poly
+	scratch    sync.Pool
+}
+
+func Precompute(mat PolyQMatrix) *PrecomputedPolyQMatrix {
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	ntt := mat.nttSlots()
+	for _, row := range ntt {
+		for _, p := range row {
+			r.MForm(p, p)
+		}
+	}
+
+	ret := &PrecomputedPolyQMatrix{r: r, rows: mat.Rows(), cols: mat.Cols(), ntt: ntt}
+	ret.scratch.New = func() any {
+		buf := make([]ring.Poly, max(ret.rows, ret.cols))
+		for i := range buf {
+			buf[i] = r.NewPoly()
+		}
+		return &buf
+	}
+
+	return ret
+}
+
+func (pre *PrecomputedPolyQMatrix) Rows() int {
+	return pre.rows
+}
+
+func (pre *PrecomputedPolyQMatrix) Cols() int {
+	return pre.cols
+}
+
+// out = A * v. out must hold Rows() polynomials and may alias v.
+func (pre *PrecomputedPolyQMatrix) MulVec(v, out vector.PolyQVector) {
+	if v.Length() != pre.cols || out.Length() != pre.rows {
+		log.Panic("MulVec: vectors don't have the matching lengths")
+	}
+
+	bufPtr := pre.scratch.Get().(*[]ring.Poly)
+	defer pre.scratch.Put(bufPtr)
+	vNTT := (*bufPtr)[:pre.cols]
+
+	for j, p := range v {
+		pre.r.NTT(p.Poly, vNTT[j])
+	}
+
+	for i := 0; i < pre.rows; i++ {
+		acc := out[i].Poly
+		acc.Zero()
+		for j := 0; j < pre.cols; j++ {
+			pre.r.MulCoeffsMontgomeryThenAdd(pre.ntt[i][j], vNTT[j], acc)
+		}
+		pre.r.INTT(acc, acc)
+	}
+}
+
+// out = A^T * v without materializing the transposed matrix. out must hold
+// Cols() polynomials and may alias v.
+func (pre *PrecomputedPolyQMatrix) MulVecTransposed(v, out vector.PolyQVector) {
+	if v.Length() != pre.rows || out.Length() != pre.cols {
+		log.Panic("MulVecTransposed: vectors don't have the matching lengths")
+	}
+
+	bufPtr := pre.scratch.Get().(*[]ring.Poly)
+	defer pre.scratch.Put(bufPtr)
+	vNTT := (*bufPtr)[:pre.rows]
+
+	for i, p := range v {
+		pre.r.NTT(p.Poly, vNTT[i])
+	}
+
+	for j := 0; j < pre.cols; j++ {
+		acc := out[j].Poly
+		acc.Zero()
+		for i := 0; i < pre.rows; i++ {
+			pre.r.MulCoeffsMontgomeryThenAdd(pre.ntt[i][j], vNTT[i], acc)
+		}
+		pre.r.INTT(acc, acc)
+	}
+}
+
+func (pre *PrecomputedPolyQMatrix) MulVecNew(v vector.PolyQVector) vector.PolyQVector {
+	out := vector.NewZeroPolyQVector(pre.rows)
+	pre.MulVec(v, out)
+	return out
+}
+
+func (pre *PrecomputedPolyQMatrix) MulVecTransposedNew(v vector.PolyQVector) vector.PolyQVector {
+	out := vector.NewZeroPolyQVector(pre.cols)
+	pre.MulVecTransposed(v, out)
+	return out
+}
@@ -0,0 +1,88 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPrecomputedMulVec(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 4, 3)
+	pre := Precompute(a)
+
+	v := vector.NewRandomPolyQVector(nil, 3)
+	if !pre.MulVecNew(v).Equals(a.VecMul(v)) {
+		t.Error("MulVec differs from VecMul")
+	}
+
+	w := vector.NewRandomPolyQVector(nil, 4)
+	if !pre.MulVecTransposedNew(w).Equals(a.Transposed().VecMul(w)) {
+		t.Error("MulVecTransposed differs from Transposed().VecMul")
+	}
+}
+
+func TestPrecomputedMulVecAliasing(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 3, 3)
+	v := vector.NewRandomPolyQVector(nil, 3)
+	expected := a.VecMul(v)
+
+	Precompute(a).MulVec(v, v)
+	if !v.Equals(expected) {
+		t.Error("MulVec with aliased output failed")
+	}
+}
+
+func TestPrecomputedDoesNotAllocate(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not reliable under the race detector")
+	}
+
+	a := NewRandomPolyQMatrix(nil, 4, 4)
+	pre := Precompute(a)
+	v := vector.NewRandomPolyQVector(nil, 4)
+	out := vector.NewZeroPolyQVector(4)
+
+	if allocs := testing.AllocsPerRun(100, func() { pre.MulVec(v, out) }); allocs > 0 {
+		t.Errorf("MulVec allocated %v times per run", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { pre.MulVecTransposed(v, out) }); allocs > 0 {
+		t.Errorf("MulVecTransposed allocated %v times per run", allocs)
+	}
+}
+
+func BenchmarkVecMul(b *testing.B) {
+	a := NewRandomPolyQMatrix(nil, 4, 4)
+	v := vector.NewRandomPolyQVector(nil, 4)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.VecMul(v)
+	}
+}
+
+func BenchmarkPrecomputedMulVec(b *testing.B) {
+	a := NewRandomPolyQMatrix(nil, 4, 4)
+	v := vector.NewRandomPolyQVector(nil, 4)
+	pre := Precompute(a)
+	out := vector.NewZeroPolyQVector(4)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pre.MulVec(v, out)
+	}
+}
+
+func BenchmarkPrecomputedMulVecTransposed(b *testing.B) {
+	a := NewRandomPolyQMatrix(nil, 4, 4)
+	v := vector.NewRandomPolyQVector(nil, 4)
+	pre := Precompute(a)
+	out := vector.NewZeroPolyQVector(4)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pre.MulVecTransposed(v, out)
+	}
+}
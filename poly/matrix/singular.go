@@ -0,0 +1,171 @@
+package matrix
+
+import (
+	"math"
+	"sort"
+)
+
+// The canonical embedding diagonalizes multiplication in Z[X]/(X^N + 1),
+// so a rows x cols module matrix splits into N independent complex
+// matrices, one per root of X^N + 1. Singular values of the flattened
+// (BigToeplitz) matrix are the union of singular values of those slots.
+func singularValues(embeddings [][][]complex128) []float64 {
+	rows := len(embeddings)
+	cols := len(embeddings[0])
+	slots := len(embeddings[0][0])
+
+	k := min(rows, cols)
+	ret := make([]float64, 0, slots*k)
+
+	gram := make([][]complex128, k)
+	for i := range gram {
+		gram[i] = make([]complex128, k)
+	}
+
+	for s := 0; s < slots; s++ {
+		// Smaller of A^H * A and A * A^H, both share the non-zero spectrum
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				sum := complex(0, 0)
+				if cols <= rows {
+					for r := 0; r < rows; r++ {
+						sum += conj(embeddings[r][i][s]) * embeddings[r][j][s]
+					}
+				} else {
+					for c := 0; c < cols; c++ {
+						sum += embeddings[i][c][s] * conj(embeddings[j][c][s])
+					}
+				}
+				gram[i][j] = sum
+			}
+		}
+
+		for _, eig := range hermitianEigenvalues(gram) {
+			ret = append(ret, math.Sqrt(math.Max(eig, 0)))
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(ret)))
+
+	return ret
+}
+
+func conj(c complex128) complex128 {
+	return complex(real(c), -imag(c))
+}
+
+// Eigenvalues of a Hermitian matrix H = X + iY through the real symmetric
+// matrix [[X, -Y], [Y, X]], whose spectrum is that of H with every value doubled
+func hermitianEigenvalues(h [][]complex128) []float64 {
+	k := len(h)
+	sym := make([][]float64, 2*k)
+	for i := range sym {
+		sym[i] = make([]float64, 2*k)
+	}
+
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			x, y := real(h[i][j]), imag(h[i][j])
+			sym[i][j] = x
+			sym[i+k][j+k] = x
+			sym[i][j+k] = -y
+			sym[i+k][j] = y
+		}
+	}
+
+	eigs := symmetricEigenvalues(sym)
+	sort.Float64s(eigs)
+
+	ret := make([]float64, k)
+	for i := range ret {
+		ret[i] = eigs[2*i]
+	}
+	return ret
+}
+
+// Cyclic Jacobi eigenvalue algorithm, destroys its input
+func symmetricEigenvalues(a [][]float64) []float64 {
+	n := len(a)
+
+	for sweep := 0; sweep < 100; sweep++ {
+		off, total := 0.0, 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				total += a[i][j] * a[i][j]
+				if i != j {
+					off += a[i][j] * a[i][j]
+				}
+			}
+		}
+		if off <= 1e-30*total {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for r := 0; r < n; r++ {
+					arp, arq := a[r][p], a[r][q]
+					a[r][p] = c*arp - s*arq
+					a[r][q] = s*arp + c*arq
+				}
+				for r := 0; r < n; r++ {
+					apr, aqr := a[p][r], a[q][r]
+					a[p][r] = c*apr - s*aqr
+					a[q][r] = s*apr + c*aqr
+				}
+			}
+		}
+	}
+
+	ret := make([]float64, n)
+	for i := range ret {
+		ret[i] = a[i][i]
+	}
+	return ret
+}
+
+// Singular values of the flattened integer matrix in descending order,
+// computed slot by slot in the canonical embedding
+func (mat PolyMatrix) SingularValues() []float64 {
+	embeddings := make([][][]complex128, mat.Rows())
+	for i, polyVec := range mat {
+		embeddings[i] = make([][]complex128, mat.Cols())
+		for j, p := range polyVec {
+			embeddings[i][j] = p.CanonicalEmbedding()
+		}
+	}
+	return singularValues(embeddings)
+}
+
+// Largest singular value, i.e. the spectral norm of x -> mat * x
+func (mat PolyMatrix) OperatorNorm() float64 {
+	return mat.SingularValues()[0]
+}
+
+// Coefficients are lifted to the centered range (-q/2, q/2] first
+func (mat PolyQMatrix) SingularValues() []float64 {
+	embeddings := make([][][]complex128, mat.Rows())
+	for i, polyQVec := range mat {
+		embeddings[i] = make([][]complex128, mat.Cols())
+		for j, p := range polyQVec {
+			embeddings[i][j] = p.CanonicalEmbedding()
+		}
+	}
+	return singularValues(embeddings)
+}
+
+func (mat PolyQMatrix) OperatorNorm() float64 {
+	return mat.SingularValues()[0]
+}
@@ -0,0 +1,95 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func newSmallPolyMatrix(rows, cols int, bound int64) PolyMatrix {
+	mat := make(PolyMatrix, rows)
+	for i := range mat {
+		mat[i] = make(vector.PolyVector, cols)
+		for j := range mat[i] {
+			p := poly.NewPoly()
+			for k := range p {
+				p[k] = rand.Int63n(bound)
+			}
+			mat[i][j] = p
+		}
+	}
+	return mat
+}
+
+// Power iteration on B^T * B as a slow reference for the largest singular value
+func largestSingularValue(b [][]int64) float64 {
+	bt := Transpose(b)
+	x := make([]float64, len(b[0]))
+	for i := range x {
+		x[i] = rand.Float64()
+	}
+
+	mul := func(m [][]int64, v []float64) []float64 {
+		ret := make([]float64, len(m))
+		for i, row := range m {
+			for j, entry := range row {
+				ret[i] += float64(entry) * v[j]
+			}
+		}
+		return ret
+	}
+
+	norm := 0.0
+	for iter := 0; iter < 500; iter++ {
+		x = mul(bt, mul(b, x))
+		norm = 0
+		for _, v := range x {
+			norm += v * v
+		}
+		norm = math.Sqrt(norm)
+		for i := range x {
+			x[i] /= norm
+		}
+	}
+	return math.Sqrt(norm)
+}
+
+func TestSingularValuesAgainstBigToeplitz(t *testing.T) {
+	mat := newSmallPolyMatrix(2, 3, 20).Q()
+	big := BigToeplitz(mat, mat.Rows(), mat.Cols())
+
+	values := mat.SingularValues()
+	if len(values) != 2*latticehelper.MainRing.N() {
+		t.Fatalf("Expected %d singular values but got %d", 2*latticehelper.MainRing.N(), len(values))
+	}
+
+	// Squared Frobenius norm is the sum of squared singular values
+	frobenius, sum := 0.0, 0.0
+	for _, row := range big {
+		for _, entry := range row {
+			frobenius += float64(entry * entry)
+		}
+	}
+	for _, v := range values {
+		sum += v * v
+	}
+	if math.Abs(frobenius-sum) > 1e-6*frobenius {
+		t.Errorf("Frobenius norm mismatch: expected %v but got %v", frobenius, sum)
+	}
+
+	expected := largestSingularValue(big)
+	if math.Abs(expected-mat.OperatorNorm()) > 1e-6*expected {
+		t.Errorf("Expected operator norm %v but got %v", expected, mat.OperatorNorm())
+	}
+}
+
+func TestPolyMatrixOperatorNormMatchesPolyQ(t *testing.T) {
+	mat := newSmallPolyMatrix(3, 2, 10)
+	if math.Abs(mat.OperatorNorm()-mat.Q().OperatorNorm()) > 1e-9*mat.OperatorNorm() {
+		t.Errorf("Expected %v but got %v", mat.OperatorNorm(), mat.Q().OperatorNorm())
+	}
+}
@@ -0,0 +1,187 @@
+package matrix
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// Every entry of the matrix in NTT form. The ring is fully split, so each
+// NTT slot is an independent copy of Z_q.
+func (mat PolyQMatrix) nttSlots() [][]ring.Poly {
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	ret := make([][]ring.Poly, mat.Rows())
+	for i, polyQVec := range mat {
+		ret[i] = make([]ring.Poly, len(polyQVec))
+		for j, p := range polyQVec {
+			ret[i][j] = r.NewPoly()
+			r.NTT(p.Poly, ret[i][j])
+		}
+	}
+	return ret
+}
+
+func slotMatrix(ntt [][]ring.Poly, slot int) intmatrix.IntMatrix {
+	level := latticehelper.MainRing.Level()
+
+	ret := intmatrix.NewZeroIntMatrix(len(ntt), len(ntt[0]))
+	for i, row := range ntt {
+		for j, p := range row {
+			ret[i][j] = int64(p.Coeffs[level][slot])
+		}
+	}
+	return ret
+}
+
+func polyQFromNTTSlots(slots []int64) poly.PolyQ {
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	ret := poly.NewPolyQ()
+	for s, value := range slots {
+		ret.Coeffs[r.Level()][s] = uint64(value)
+	}
+	r.INTT(ret.Poly, ret.Poly)
+
+	return ret
+}
+
+// Some x with mat * x = t over Rq, obtained by Gaussian elimination in
+// every NTT slot. Returns intmatrix.ErrNoSolution (wrapped) when the system
+// is inconsistent in at least one slot.
+func (mat PolyQMatrix) Solve(t vector.PolyQVector) (vector.PolyQVector, error) {
+	if t.Length() != mat.Rows() {
+		log.Panic("Solve: length of target is not equal to number of rows")
+	}
+
+	n := latticehelper.MainRing.N()
+	q := latticehelper.MainRing.Modulus().Int64()
+
+	matNTT := mat.nttSlots()
+	tNTT := PolyQMatrix{t}.Transposed().nttSlots()
+
+	solution := make([][]int64, mat.Cols())
+	for j := range solution {
+		solution[j] = make([]int64, n)
+	}
+
+	for s := 0; s < n; s++ {
+		target := make([]int64, t.Length())
+		for i := range target {
+			target[i] = int64(tNTT[i][0].Coeffs[latticehelper.MainRing.Level()][s])
+		}
+
+		x, err := slotMatrix(matNTT, s).SolveMod(target, q)
+		if err != nil {
+			return nil, fmt.Errorf("Solve: NTT slot %d: %w", s, err)
+		}
+
+		for j, value := range x {
+			solution[j][s] = value
+		}
+	}
+
+	ret := make(vector.PolyQVector, mat.Cols())
+	for j := range ret {
+		ret[j] = polyQFromNTTSlots(solution[j])
+	}
+	return ret, nil
+}
+
+// Generators of the Rq-module ker(mat), one vector per row. For every
+// column that is free in some NTT slot there is one generator, which is
+// zero in the slots where that column has a pivot. When all slots have the
+// same pivot columns (the generic case) the generators form a basis.
+// The result has no rows when the kernel is trivial.
+func (mat PolyQMatrix) Kernel() PolyQMatrix {
+	n := latticehelper.MainRing.N()
+	q := latticehelper.MainRing.Modulus().Int64()
+	cols := mat.Cols()
+
+	matNTT := mat.nttSlots()
+
+	// generators[free][j][s], nil for columns that have a pivot in every slot
+	generators := make([][][]int64, cols)
+
+	for s := 0; s < n; s++ {
+		slot := slotMatrix(matNTT, s)
+		_, pivots := slot.RowEchelonMod(q)
+		kernel := slot.KernelMod(q)
+
+		isPivot := make([]bool, cols)
+		for _, col := range pivots {
+			isPivot[col] = true
+		}
+
+		// KernelMod returns one basis vector per free column, in column order
+		k := 0
+		for free := 0; free < cols; free++ {
+			if isPivot[free] {
+				continue
+			}
+
+			if generators[free] == nil {
+				generators[free] = make([][]int64, cols)
+				for j := range generators[free] {
+					generators[free][j] = make([]int64, n)
+				}
+			}
+
+			for j := 0; j < cols; j++ {
+				generators[free][j][s] = kernel[k][j]
+			}
+			k++
+		}
+	}
+
+	ret := make(PolyQMatrix, 0, cols)
+	for _, slots := range generators {
+		if slots == nil {
+			continue
+		}
+
+		generator := make(vector.PolyQVector, cols)
+		for j := range generator {
+			generator[j] = polyQFromNTTSlots(slots[j])
+		}
+		ret = append(ret, generator)
+	}
+	return ret
+}
+
+// Some x with mat * x = t over Zq, computed on the flat view IntMatrix.
+// Unlike Solve, this does not need the ring to split.
+func (mat PolyQMatrix) SolveFlat(t vector.PolyQVector) (vector.PolyQVector, error) {
+	if t.Length() != mat.Rows() {
+		log.Panic("SolveFlat: length of target is not equal to number of rows")
+	}
+
+	x, err := mat.IntMatrix().SolveMod(t.Listize(), latticehelper.MainRing.Modulus().Int64())
+	if err != nil {
+		return nil, fmt.Errorf("SolveFlat: %w", err)
+	}
+
+	return vector.NewPolyQVectorFromList(x)
+}
+
+// Zq-basis of ker(mat) in the flat view, every row is one basis vector
+// reshaped back into a PolyQVector. The result has no rows when the kernel
+// is trivial.
+func (mat PolyQMatrix) KernelFlat() PolyQMatrix {
+	kernel := mat.IntMatrix().KernelMod(latticehelper.MainRing.Modulus().Int64())
+
+	ret := make(PolyQMatrix, kernel.Rows())
+	for i, row := range kernel {
+		vec, err := vector.NewPolyQVectorFromList(row)
+		if err != nil {
+			panic(err)
+		}
+		ret[i] = vec
+	}
+	return ret
+}
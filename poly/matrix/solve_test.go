@@ -0,0 +1,73 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPolyQMatrixSolve(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	target := a.VecMul(vector.NewRandomPolyQVector(nil, 3))
+
+	x, err := a.Solve(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.VecMul(x).Equals(target) {
+		t.Error("Solve returned a wrong solution")
+	}
+
+	x, err = a.SolveFlat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.VecMul(x).Equals(target) {
+		t.Error("SolveFlat returned a wrong solution")
+	}
+}
+
+func TestPolyQMatrixSolveInconsistent(t *testing.T) {
+	p := poly.NewPolyQFromCoeffs(1, 2, 3)
+	a := PolyQMatrix{{p}, {p}}
+	target := vector.PolyQVector{poly.NewConstantPolyQ(1), poly.NewConstantPolyQ(2)}
+
+	if _, err := a.Solve(target); !errors.Is(err, intmatrix.ErrNoSolution) {
+		t.Errorf("Expected ErrNoSolution but got %v", err)
+	}
+	if _, err := a.SolveFlat(target); !errors.Is(err, intmatrix.ErrNoSolution) {
+		t.Errorf("Expected ErrNoSolution but got %v", err)
+	}
+}
+
+func TestPolyQMatrixKernel(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 2, 3)
+	zero := vector.NewZeroPolyQVector(2)
+
+	kernel := a.Kernel()
+	if kernel.Rows() != 1 {
+		t.Fatalf("Expected one generator but got %d", kernel.Rows())
+	}
+	for _, generator := range kernel {
+		if !a.VecMul(generator).Equals(zero) {
+			t.Error("Kernel generator is not in the kernel")
+		}
+	}
+
+	kernelFlat := a.KernelFlat()
+	if kernelFlat.Rows() != poly.NewPolyQ().Length() {
+		t.Fatalf("Expected %d basis vectors but got %d", poly.NewPolyQ().Length(), kernelFlat.Rows())
+	}
+	for _, basisVec := range kernelFlat {
+		if !a.VecMul(basisVec).Equals(zero) {
+			t.Error("Flat kernel vector is not in the kernel")
+		}
+	}
+
+	if NewIdentityPolyQMatrix(2).Kernel().Rows() != 0 {
+		t.Error("Expected trivial kernel")
+	}
+}
@@ -0,0 +1,120 @@
+package matrix
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+// Rows and entries are allocated as they arrive, so a forged header cannot
+// make ReadFrom reserve more than this many of either up front
+const maxPreallocated = 1024
+
+// Streams the MarshalBinary encoding one polynomial at a time
+func (mat PolyQMatrix) WriteTo(w io.Writer) (int64, error) {
+	return mat.WriteToWithOptions(w, wire.StreamOptions{})
+}
+
+// Same as WriteTo, opts.RowChecksums appends a CRC-32C to every row
+func (mat PolyQMatrix) WriteToWithOptions(w io.Writer, opts wire.StreamOptions) (int64, error) {
+	cols, err := checkRectangular(func(i int) int { return mat[i].Length() }, mat.Rows())
+	if err != nil {
+		return 0, err
+	}
+
+	// Checked before anything is written, so an error means 0 bytes
+	for i, polyQVec := range mat {
+		for j, p := range polyQVec {
+			if err := wire.CheckPolyQ(p.Coeffs); err != nil {
+				return 0, fmt.Errorf("WriteTo: entry (%d, %d): %w", i, j, err)
+			}
+		}
+	}
+
+	sw := wire.NewWriter(w, wire.TagPolyQMatrix, mat.Rows(), cols, opts)
+	for _, polyQVec := range mat {
+		for _, p := range polyQVec {
+			sw.WritePolyQ(p.Coeffs)
+		}
+	}
+	return sw.Result()
+}
+
+// Reads a stream written by WriteTo, with or without row checksums, or a
+// MarshalBinary encoding. Nothing past the matrix is consumed.
+func (mat *PolyQMatrix) ReadFrom(r io.Reader) (int64, error) {
+	sr, err := wire.NewReader(r, wire.TagPolyQMatrix)
+	if err != nil {
+		return sr.Count(), err
+	}
+
+	rows, cols := sr.Dims()
+	ret := make(PolyQMatrix, 0, min(rows, maxPreallocated))
+	for i := 0; i < rows; i++ {
+		row := make(vector.PolyQVector, 0, min(cols, maxPreallocated))
+		for j := 0; j < cols; j++ {
+			p := poly.NewPolyQ()
+			if err := sr.ReadPolyQ(p.Coeffs); err != nil {
+				return sr.Count(), fmt.Errorf("ReadFrom: entry (%d, %d): %w", i, j, err)
+			}
+			row = append(row, p)
+		}
+		ret = append(ret, row)
+	}
+	*mat = ret
+	return sr.Count(), nil
+}
+
+func (mat PolyMatrix) WriteTo(w io.Writer) (int64, error) {
+	return mat.WriteToWithOptions(w, wire.StreamOptions{})
+}
+
+func (mat PolyMatrix) WriteToWithOptions(w io.Writer, opts wire.StreamOptions) (int64, error) {
+	cols, err := checkRectangular(func(i int) int { return mat[i].Length() }, mat.Rows())
+	if err != nil {
+		return 0, err
+	}
+
+	// Checked before anything is written, so an error means 0 bytes
+	for i, polyVec := range mat {
+		for j, p := range polyVec {
+			if p.Length() != wire.PolySize()/8 {
+				return 0, fmt.Errorf("WriteTo: entry (%d, %d) has %d coefficients", i, j, p.Length())
+			}
+		}
+	}
+
+	sw := wire.NewWriter(w, wire.TagPolyMatrix, mat.Rows(), cols, opts)
+	for _, polyVec := range mat {
+		for _, p := range polyVec {
+			sw.WritePoly(p)
+		}
+	}
+	return sw.Result()
+}
+
+func (mat *PolyMatrix) ReadFrom(r io.Reader) (int64, error) {
+	sr, err := wire.NewReader(r, wire.TagPolyMatrix)
+	if err != nil {
+		return sr.Count(), err
+	}
+
+	rows, cols := sr.Dims()
+	ret := make(PolyMatrix, 0, min(rows, maxPreallocated))
+	for i := 0; i < rows; i++ {
+		row := make(vector.PolyVector, 0, min(cols, maxPreallocated))
+		for j := 0; j < cols; j++ {
+			p := poly.NewPoly()
+			if err := sr.ReadPoly(p); err != nil {
+				return sr.Count(), fmt.Errorf("ReadFrom: entry (%d, %d): %w", i, j, err)
+			}
+			row = append(row, p)
+		}
+		ret = append(ret, row)
+	}
+	*mat = ret
+	return sr.Count(), nil
+}
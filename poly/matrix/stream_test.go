@@ -0,0 +1,148 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQMatrixStreamRoundTrip(t *testing.T) {
+	mat := NewRandomPolyQMatrix(nil, 3, 2)
+	marshaled, _ := mat.MarshalBinary()
+
+	for _, opts := range []wire.StreamOptions{{}, {RowChecksums: true}} {
+		var buf bytes.Buffer
+		n, err := mat.WriteToWithOptions(&buf, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("%+v: WriteTo reported %d bytes, wrote %d", opts, n, buf.Len())
+		}
+		if !opts.RowChecksums && !bytes.Equal(buf.Bytes(), marshaled) {
+			t.Error("WriteTo without checksums differs from MarshalBinary")
+		}
+
+		var decoded PolyQMatrix
+		read, err := decoded.ReadFrom(&buf)
+		if err != nil {
+			t.Fatalf("%+v: %v", opts, err)
+		}
+		if read != n || !decoded.Equals(mat) {
+			t.Errorf("%+v: stream round trip failed", opts)
+		}
+	}
+}
+
+func TestPolyMatrixStreamRoundTrip(t *testing.T) {
+	a := NewRandomPolyMatrix(2, 3)
+	b := NewRandomPolyMatrix(1, 1)
+
+	// Two matrices back to back, ReadFrom must stop at the end of the first
+	var buf bytes.Buffer
+	if _, err := a.WriteToWithOptions(&buf, wire.StreamOptions{RowChecksums: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var decodedA, decodedB PolyMatrix
+	if _, err := decodedA.ReadFrom(&buf); err != nil || !decodedA.Equals(a) {
+		t.Fatalf("first matrix not decoded: %v", err)
+	}
+	if _, err := decodedB.ReadFrom(&buf); err != nil || !decodedB.Equals(b) {
+		t.Fatalf("second matrix not decoded: %v", err)
+	}
+	if _, err := decodedB.ReadFrom(&buf); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("empty stream: %v", err)
+	}
+}
+
+func TestPolyQMatrixStreamErrors(t *testing.T) {
+	mat := NewRandomPolyQMatrix(nil, 2, 2)
+	var buf bytes.Buffer
+	mat.WriteToWithOptions(&buf, wire.StreamOptions{RowChecksums: true})
+	valid := buf.Bytes()
+
+	var decoded PolyQMatrix
+
+	corrupt := append([]byte(nil), valid...)
+	corrupt[len(corrupt)-1] ^= 1
+	if _, err := decoded.ReadFrom(bytes.NewReader(corrupt)); !errors.Is(err, wire.ErrChecksum) {
+		t.Errorf("corrupted checksum accepted: %v", err)
+	}
+
+	// A header claiming 2^32-1 rows only fails once the data runs out
+	forged := append([]byte(nil), valid[:wire.HeaderSize]...)
+	binary.LittleEndian.PutUint32(forged[14:], 1<<32-1)
+	if _, err := decoded.ReadFrom(bytes.NewReader(forged)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("forged dimensions accepted: %v", err)
+	}
+
+	// Same for 2^32-1 columns, entries are not reserved up front either
+	forged = append([]byte(nil), valid[:wire.HeaderSize]...)
+	binary.LittleEndian.PutUint32(forged[18:], 1<<32-1)
+	if _, err := decoded.ReadFrom(bytes.NewReader(forged)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("forged columns accepted: %v", err)
+	}
+
+	// Empty rows read no data, so a rows x 0 header is rejected right away
+	binary.LittleEndian.PutUint32(forged[14:], 1<<32-1)
+	binary.LittleEndian.PutUint32(forged[18:], 0)
+	if n, err := decoded.ReadFrom(bytes.NewReader(forged)); !errors.Is(err, wire.ErrMalformed) || n != wire.HeaderSize {
+		t.Errorf("%d empty rows accepted: %v", uint32(1<<32-1), err)
+	}
+
+	if _, err := decoded.ReadFrom(io.LimitReader(bytes.NewReader(valid), 100)); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("truncated stream accepted: %v", err)
+	}
+
+	var wrongType PolyMatrix
+	if _, err := wrongType.ReadFrom(bytes.NewReader(valid)); !errors.Is(err, wire.ErrType) {
+		t.Errorf("PolyQMatrix stream decoded as PolyMatrix: %v", err)
+	}
+}
+
+func TestStreamWriteErrors(t *testing.T) {
+	// rows x 0 matrices cannot be read back, so they are not written
+	var buf bytes.Buffer
+	empty := PolyQMatrix{{}, {}}
+	if _, err := empty.WriteTo(&buf); err == nil {
+		t.Error("2x0 matrix streamed")
+	}
+
+	// A bad entry is found before the header goes out
+	mat := NewRandomPolyMatrix(2, 2)
+	mat[1][1] = mat[1][1][:3]
+	buf.Reset()
+	n, err := mat.WriteTo(&buf)
+	if err == nil || n != 0 || buf.Len() != 0 {
+		t.Errorf("short entry: wrote %d bytes, reported %d, %v", buf.Len(), n, err)
+	}
+
+	// Same for a PolyQ without coefficients, which MarshalBinary rejects too
+	buf.Reset()
+	bad := PolyQMatrix{{poly.PolyQ{}}}
+	n, err = bad.WriteTo(&buf)
+	if err == nil || n != 0 || buf.Len() != 0 {
+		t.Errorf("empty PolyQ: wrote %d bytes, reported %d, %v", buf.Len(), n, err)
+	}
+	if _, err := bad.MarshalBinary(); err == nil {
+		t.Error("empty PolyQ encoded")
+	}
+
+	// The Writer itself stops at the bad polynomial instead of encoding it
+	buf.Reset()
+	sw := wire.NewWriter(&buf, wire.TagPolyQMatrix, 1, 2, wire.StreamOptions{})
+	sw.WritePolyQ(nil)
+	sw.WritePolyQ(poly.NewPolyQ().Coeffs)
+	if n, err := sw.Result(); err == nil || n != wire.HeaderSize {
+		t.Errorf("Writer after a bad polynomial: %d bytes, %v", n, err)
+	}
+}
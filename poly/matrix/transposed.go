@@ -0,0 +1,88 @@
+package matrix
+
+import (
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"github.com/tuneinsight/lattigo/v5/ring"
+)
+
+// Row vector times matrix, v^T * mat, without materializing the transpose
+func (mat PolyQMatrix) LeftVecMul(inputPolyQVector vector.PolyQVector) vector.PolyQVector {
+	return mat.LeftVecMulParallel(inputPolyQVector, latticehelper.Parallelism())
+}
+
+// Same as LeftVecMul, entries of the result are computed by up to workers goroutines
+func (mat PolyQMatrix) LeftVecMulParallel(inputPolyQVector vector.PolyQVector, workers int) vector.PolyQVector {
+	if inputPolyQVector.Length() != mat.Rows() {
+		log.Panic("LeftVecMul: vector length is not equal to number of rows")
+	}
+	newVec := make(vector.PolyQVector, mat.Cols())
+
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	inputNTT := make([]ring.Poly, inputPolyQVector.Length())
+	for i, p := range inputPolyQVector {
+		inputNTT[i] = r.NewPoly()
+		r.NTT(p.Poly, inputNTT[i])
+	}
+
+	workers = max(1, min(workers, mat.Cols()))
+	buffers := make([]ring.Poly, workers)
+	for w := range buffers {
+		buffers[w] = r.NewPoly()
+	}
+
+	parallelFor(mat.Cols(), workers, func(worker, j int) {
+		matNTT := buffers[worker]
+		currentPoly := poly.NewPolyQ()
+
+		for i := 0; i < mat.Rows(); i++ {
+			r.NTT(mat[i][j].Poly, matNTT)
+			r.MulCoeffsBarrettThenAdd(inputNTT[i], matNTT, currentPoly.Poly)
+		}
+		r.INTT(currentPoly.Poly, currentPoly.Poly)
+
+		newVec[j] = currentPoly
+	})
+
+	return newVec
+}
+
+// mat^T * v without materializing the transpose. Rq is commutative, so
+// this equals LeftVecMul.
+func (mat PolyQMatrix) MulTransposed(inputPolyQVector vector.PolyQVector) vector.PolyQVector {
+	return mat.LeftVecMul(inputPolyQVector)
+}
+
+// Row vector times matrix, v^T * mat, without materializing the transpose
+func (mat PolyMatrix) LeftVecMul(inputPolyVector vector.PolyVector) vector.PolyVector {
+	return mat.LeftVecMulParallel(inputPolyVector, latticehelper.Parallelism())
+}
+
+// Same as LeftVecMul, entries of the result are computed by up to workers goroutines
+func (mat PolyMatrix) LeftVecMulParallel(inputPolyVector vector.PolyVector, workers int) vector.PolyVector {
+	if inputPolyVector.Length() != mat.Rows() {
+		log.Panic("LeftVecMul: vector length is not equal to number of rows")
+	}
+
+	ret := make(vector.PolyVector, mat.Cols())
+
+	parallelFor(len(ret), workers, func(_, j int) {
+		currentPoly := make(poly.Poly, mat[0][0].Length())
+
+		for i := 0; i < inputPolyVector.Length(); i++ {
+			currentPoly = currentPoly.Add(inputPolyVector[i].Mul(mat[i][j]))
+		}
+
+		ret[j] = currentPoly
+	})
+	return ret
+}
+
+// mat^T * v without materializing the transpose, equal to LeftVecMul
+func (mat PolyMatrix) MulTransposed(inputPolyVector vector.PolyVector) vector.PolyVector {
+	return mat.LeftVecMul(inputPolyVector)
+}
@@ -0,0 +1,56 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+func TestPolyQMatrixLeftVecMul(t *testing.T) {
+	a := NewRandomPolyQMatrix(nil, 3, 4)
+	v := vector.NewRandomPolyQVector(nil, 3)
+
+	expected := a.Transposed().VecMul(v)
+	if !a.LeftVecMul(v).Equals(expected) {
+		t.Error("LeftVecMul differs from Transposed().VecMul")
+	}
+	if !a.MulTransposed(v).Equals(expected) {
+		t.Error("MulTransposed differs from Transposed().VecMul")
+	}
+	for _, workers := range []int{1, 2, 8} {
+		if !a.LeftVecMulParallel(v, workers).Equals(expected) {
+			t.Errorf("LeftVecMulParallel with %d workers differs", workers)
+		}
+	}
+
+	// v^T * (A * w) = (v^T * A) * w
+	w := vector.NewRandomPolyQVector(nil, 4)
+	if !v.DotProduct(a.VecMul(w)).Equals(a.LeftVecMul(v).DotProduct(w)) {
+		t.Error("LeftVecMul is not the adjoint of VecMul")
+	}
+}
+
+func TestPolyMatrixLeftVecMul(t *testing.T) {
+	a := newSmallPolyMatrix(2, 3, 10)
+	v := vector.PolyVector{newSmallPolyMatrix(1, 1, 10)[0][0], newSmallPolyMatrix(1, 1, 10)[0][0]}
+
+	expected := a.Transposed().VecMul(v)
+	if !a.LeftVecMul(v).Equals(expected) {
+		t.Error("LeftVecMul differs from Transposed().VecMul")
+	}
+	if !a.MulTransposed(v).Equals(expected) {
+		t.Error("MulTransposed differs from Transposed().VecMul")
+	}
+}
+
+func TestOuterProductAsMatrix(t *testing.T) {
+	v := vector.NewRandomPolyQVector(nil, 2)
+	w := vector.NewRandomPolyQVector(nil, 3)
+	x := vector.NewRandomPolyQVector(nil, 3)
+
+	// (v w^T) x = v * <w, x>
+	outer := PolyQMatrix(v.OuterProduct(w))
+	if !outer.VecMul(x).Equals(v.ScaledByPolyQ(w.DotProduct(x))) {
+		t.Error("outer product does not act as v * <w, x>")
+	}
+}
@@ -0,0 +1,228 @@
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// Bit packing as in FIPS 203 ByteEncode/ByteDecode and FIPS 204
+// SimpleBitPack/BitPack: coefficients are written with d bits each,
+// least significant bit first, into consecutive bytes.
+//
+//	PackBits(d)          = ByteEncode_d = SimpleBitPack(w, 2^d - 1)
+//	PackBounded(-a, b)   = BitPack(w, a, b), which stores b - w_i
+
+var ErrPackRange = errors.New("pack: coefficient out of range")
+
+func checkWidth(d int) error {
+	if d < 1 || d > 64 {
+		return fmt.Errorf("%w: bit width %d is not in [1, 64]", ErrPackRange, d)
+	}
+	return nil
+}
+
+// Bytes taken by one packed polynomial with d bits per coefficient
+func PackedSize(d int) int {
+	return (latticehelper.MainRing.N()*d + 7) / 8
+}
+
+func packBits(values []uint64, d int) []byte {
+	out := make([]byte, (len(values)*d+7)/8)
+	pos := 0
+	for _, v := range values {
+		for k := 0; k < d; {
+			n := min(8-pos%8, d-k)
+			out[pos/8] |= byte((v>>k)&(1<<n-1)) << (pos % 8)
+			pos += n
+			k += n
+		}
+	}
+	return out
+}
+
+func unpackBits(data []byte, d, count int) ([]uint64, error) {
+	if len(data) != (count*d+7)/8 {
+		return nil, fmt.Errorf("pack: %d bytes, want %d", len(data), (count*d+7)/8)
+	}
+
+	values := make([]uint64, count)
+	pos := 0
+	for i := range values {
+		for k := 0; k < d; {
+			n := min(8-pos%8, d-k)
+			values[i] |= uint64(data[pos/8]>>(pos%8)&(1<<n-1)) << k
+			pos += n
+			k += n
+		}
+	}
+
+	if pos%8 != 0 && data[len(data)-1]>>(pos%8) != 0 {
+		return nil, errors.New("pack: non-zero padding bits")
+	}
+	return values, nil
+}
+
+// A range of one value would pack into 0 bits, which vectors cannot
+// take their length from, so it is rejected like an empty one
+func boundedWidth(lo, hi int64) (int, error) {
+	if lo >= hi {
+		return 0, fmt.Errorf("%w: range [%d, %d] has less than two values", ErrPackRange, lo, hi)
+	}
+	return bits.Len64(uint64(hi - lo)), nil
+}
+
+// Coefficients must lie in [0, 2^d)
+func (poly PolyQ) PackBits(d int) ([]byte, error) {
+	if err := checkWidth(d); err != nil {
+		return nil, err
+	}
+
+	values := make([]uint64, poly.Length())
+	for i, coeff := range poly.Coeffs[latticehelper.MainRing.Level()] {
+		if d < 64 && coeff>>d != 0 {
+			return nil, fmt.Errorf("%w: %d does not fit %d bits", ErrPackRange, coeff, d)
+		}
+		values[i] = coeff
+	}
+	return packBits(values, d), nil
+}
+
+// Inverse of PackBits, rejects coefficients that are not reduced mod q
+func (poly *PolyQ) UnpackBits(data []byte, d int) error {
+	if err := checkWidth(d); err != nil {
+		return err
+	}
+
+	values, err := unpackBits(data, d, latticehelper.MainRing.N())
+	if err != nil {
+		return err
+	}
+
+	q := latticehelper.MainRing.Modulus().Uint64()
+	coeffs := make([]int64, len(values))
+	for i, v := range values {
+		if v >= q {
+			return fmt.Errorf("%w: %d is not reduced mod %d", ErrPackRange, v, q)
+		}
+		coeffs[i] = int64(v)
+	}
+
+	*poly = NewPolyQFromCoeffs(coeffs...)
+	return nil
+}
+
+// Centered coefficients must lie in [lo, hi]. Each is stored as hi - c
+// with bitlen(hi - lo) bits.
+func (poly PolyQ) PackBounded(lo, hi int64) ([]byte, error) {
+	d, err := boundedWidth(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	q := latticehelper.MainRing.Modulus().Int64()
+
+	values := make([]uint64, poly.Length())
+	for i, coeff := range poly.Listize() {
+		c := CenteredModulo(coeff, q)
+		if c < lo || c > hi {
+			return nil, fmt.Errorf("%w: %d is not in [%d, %d]", ErrPackRange, c, lo, hi)
+		}
+		values[i] = uint64(hi - c)
+	}
+	return packBits(values, d), nil
+}
+
+func (poly *PolyQ) UnpackBounded(data []byte, lo, hi int64) error {
+	coeffs, err := unpackBounded(data, lo, hi)
+	if err != nil {
+		return err
+	}
+	*poly = NewPolyQFromCoeffs(coeffs...)
+	return nil
+}
+
+func unpackBounded(data []byte, lo, hi int64) ([]int64, error) {
+	d, err := boundedWidth(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := unpackBits(data, d, latticehelper.MainRing.N())
+	if err != nil {
+		return nil, err
+	}
+
+	coeffs := make([]int64, len(values))
+	for i, v := range values {
+		if v > uint64(hi-lo) {
+			return nil, fmt.Errorf("%w: %d is not in [%d, %d]", ErrPackRange, hi-int64(v), lo, hi)
+		}
+		coeffs[i] = hi - int64(v)
+	}
+	return coeffs, nil
+}
+
+// Coefficients must lie in [0, 2^d)
+func (coeffs Poly) PackBits(d int) ([]byte, error) {
+	if err := checkWidth(d); err != nil {
+		return nil, err
+	}
+
+	values := make([]uint64, len(coeffs))
+	for i, coeff := range coeffs {
+		if coeff < 0 || (d < 64 && uint64(coeff)>>d != 0) {
+			return nil, fmt.Errorf("%w: %d does not fit %d bits", ErrPackRange, coeff, d)
+		}
+		values[i] = uint64(coeff)
+	}
+	return packBits(values, d), nil
+}
+
+func (coeffs *Poly) UnpackBits(data []byte, d int) error {
+	if err := checkWidth(d); err != nil {
+		return err
+	}
+
+	values, err := unpackBits(data, d, latticehelper.MainRing.N())
+	if err != nil {
+		return err
+	}
+
+	ret := NewPoly()
+	for i, v := range values {
+		if int64(v) < 0 {
+			return fmt.Errorf("%w: %d does not fit an int64", ErrPackRange, v)
+		}
+		ret[i] = int64(v)
+	}
+	*coeffs = ret
+	return nil
+}
+
+// Coefficients must lie in [lo, hi], see PolyQ.PackBounded
+func (coeffs Poly) PackBounded(lo, hi int64) ([]byte, error) {
+	d, err := boundedWidth(lo, hi)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]uint64, len(coeffs))
+	for i, c := range coeffs {
+		if c < lo || c > hi {
+			return nil, fmt.Errorf("%w: %d is not in [%d, %d]", ErrPackRange, c, lo, hi)
+		}
+		values[i] = uint64(hi - c)
+	}
+	return packBits(values, d), nil
+}
+
+func (coeffs *Poly) UnpackBounded(data []byte, lo, hi int64) error {
+	ret, err := unpackBounded(data, lo, hi)
+	if err != nil {
+		return err
+	}
+	*coeffs = ret
+	return nil
+}
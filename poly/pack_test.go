@@ -0,0 +1,159 @@
+package poly
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// FIPS 203 ByteEncode_12 packs 0x001, 0x002 into 01 20 00
+func TestPackBitsByteEncode(t *testing.T) {
+	b, err := NewPolyQFromCoeffs(1, 2, 0xfff).PackBits(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != PackedSize(12) || len(b) != 128*12/8 {
+		t.Fatalf("packed into %d bytes", len(b))
+	}
+	if !bytes.Equal(b[:6], []byte{0x01, 0x20, 0x00, 0xff, 0x0f, 0x00}) {
+		t.Errorf("unexpected encoding % x", b[:6])
+	}
+
+	var p PolyQ
+	if err := p.UnpackBits(b, 12); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equals(NewPolyQFromCoeffs(1, 2, 0xfff)) {
+		t.Error("UnpackBits does not invert PackBits")
+	}
+}
+
+// FIPS 204 BitPack(w, 2, 2) stores 2 - w_i in 3 bits
+func TestPackBoundedBitPack(t *testing.T) {
+	b, err := NewPolyQFromCoeffs(2, -2, 1).PackBounded(-2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0 | 4 << 3 | 1 << 6, then the zero coefficients stored as 2
+	if len(b) != 128*3/8 || b[0] != 0x60 || b[1] != 0x24 {
+		t.Errorf("unexpected encoding % x", b[:2])
+	}
+
+	var p PolyQ
+	if err := p.UnpackBounded(b, -2, 2); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Equals(NewPolyQFromCoeffs(2, -2, 1)) {
+		t.Error("UnpackBounded does not invert PackBounded")
+	}
+}
+
+func TestPackBoundedRoundTrip(t *testing.T) {
+	gamma1 := int64(1 << 17)
+	p := NewRandomPolyQWithMaxInfNorm(nil, gamma1-1)
+
+	b, err := p.PackBounded(-(gamma1 - 1), gamma1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != PackedSize(18) {
+		t.Fatalf("packed into %d bytes", len(b))
+	}
+
+	var decoded PolyQ
+	if err := decoded.UnpackBounded(b, -(gamma1 - 1), gamma1); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(p) {
+		t.Error("bounded round trip failed")
+	}
+}
+
+func TestPackRejectsOutOfRange(t *testing.T) {
+	if _, err := NewPolyQFromCoeffs(3).PackBounded(-2, 2); !errors.Is(err, ErrPackRange) {
+		t.Errorf("coefficient 3 packed into [-2, 2]: %v", err)
+	}
+	if _, err := NewPolyQFromCoeffs(1 << 10).PackBits(10); !errors.Is(err, ErrPackRange) {
+		t.Errorf("2^10 packed into 10 bits: %v", err)
+	}
+
+	// 7 in 3 bits decodes to 2 - 7 = -5
+	b := make([]byte, PackedSize(3))
+	b[0] = 7
+	var p PolyQ
+	if err := p.UnpackBounded(b, -2, 2); !errors.Is(err, ErrPackRange) {
+		t.Errorf("out of range BitPack data accepted: %v", err)
+	}
+
+	// 2^32 - 1 is not reduced mod q
+	b = make([]byte, PackedSize(32))
+	copy(b, []byte{0xff, 0xff, 0xff, 0xff})
+	if err := p.UnpackBits(b, 32); !errors.Is(err, ErrPackRange) {
+		t.Errorf("unreduced coefficient accepted: %v", err)
+	}
+
+	if err := p.UnpackBits(b[1:], 32); err == nil {
+		t.Error("truncated data accepted")
+	}
+}
+
+// Bad widths and ranges are errors, not panics
+func TestPackRejectsBadArguments(t *testing.T) {
+	var p PolyQ
+	var q Poly
+	for _, d := range []int{0, -1, 65} {
+		errs := []error{p.UnpackBits(nil, d), q.UnpackBits(nil, d)}
+		_, err := NewPolyQ().PackBits(d)
+		errs = append(errs, err)
+		_, err = NewPoly().PackBits(d)
+		errs = append(errs, err)
+		for i, err := range errs {
+			if !errors.Is(err, ErrPackRange) {
+				t.Errorf("width %d, case %d: %v", d, i, err)
+			}
+		}
+	}
+
+	// Empty ranges and ranges of a single value
+	for _, r := range [][2]int64{{1, -1}, {3, 3}} {
+		errs := []error{p.UnpackBounded(nil, r[0], r[1]), q.UnpackBounded(nil, r[0], r[1])}
+		_, err := NewPolyQ().PackBounded(r[0], r[1])
+		errs = append(errs, err)
+		_, err = NewPoly().PackBounded(r[0], r[1])
+		errs = append(errs, err)
+		for i, err := range errs {
+			if !errors.Is(err, ErrPackRange) {
+				t.Errorf("range %v, case %d: %v", r, i, err)
+			}
+		}
+	}
+}
+
+func TestPolyPack(t *testing.T) {
+	p := NewPolyFromCoeffs(5, -3, 0, 4)
+
+	b, err := p.PackBounded(-4, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Poly
+	if err := decoded.UnpackBounded(b, -4, 5); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(p) {
+		t.Error("Poly bounded round trip failed")
+	}
+
+	if _, err := p.PackBits(4); !errors.Is(err, ErrPackRange) {
+		t.Error("negative coefficient packed with PackBits")
+	}
+
+	p = NewPolyFromCoeffs(1023, 0, 512)
+	b, err = p.PackBits(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnpackBits(b, 10); err != nil || !decoded.Equals(p) {
+		t.Error("Poly bit round trip failed")
+	}
+}
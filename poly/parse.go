@@ -0,0 +1,177 @@
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+var ErrSyntax = errors.New("parse: invalid syntax")
+
+type term struct {
+	coeff int64
+	exp   int
+}
+
+// Accepts the String form, e.g. "3 + 2*x + x^5" or "1 + -3*x^2", with
+// optional "*", "X" for "x" and "-" between terms. Powers x^k with k >= N
+// are reduced by x^N = -1. Input starting with "[" is read as a
+// coefficient list in CoeffString form.
+func ParsePoly(s string) (Poly, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		var ret Poly
+		if err := ret.UnmarshalText([]byte(s)); err != nil {
+			return nil, fmt.Errorf("ParsePoly: %w", err)
+		}
+		return ret, nil
+	}
+
+	terms, err := parseTerms(s)
+	if err != nil {
+		return nil, fmt.Errorf("ParsePoly: %w", err)
+	}
+
+	ret := NewPoly()
+	for _, t := range terms {
+		sum, overflow := addInt64(ret[t.exp], t.coeff)
+		if overflow {
+			return nil, fmt.Errorf("ParsePoly: %w: coefficient of x^%d overflows", ErrSyntax, t.exp)
+		}
+		ret[t.exp] = sum
+	}
+	return ret, nil
+}
+
+// Same syntax as ParsePoly. Coefficients may be negative or centered and
+// are reduced mod q.
+func ParsePolyQ(s string) (PolyQ, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") {
+		var ret PolyQ
+		if err := ret.UnmarshalText([]byte(s)); err != nil {
+			return PolyQ{}, fmt.Errorf("ParsePolyQ: %w", err)
+		}
+		return ret, nil
+	}
+
+	terms, err := parseTerms(s)
+	if err != nil {
+		return PolyQ{}, fmt.Errorf("ParsePolyQ: %w", err)
+	}
+
+	q := latticehelper.MainRing.Modulus().Int64()
+	coeffs := make([]int64, latticehelper.MainRing.N())
+	for _, t := range terms {
+		coeffs[t.exp] = latticehelper.PositiveMod(coeffs[t.exp]+latticehelper.PositiveMod(t.coeff, q), q)
+	}
+	return NewPolyQFromCoeffs(coeffs...), nil
+}
+
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, (a >= 0) == (b >= 0) && (sum >= 0) != (a >= 0)
+}
+
+func parseTerms(s string) ([]term, error) {
+	n := latticehelper.MainRing.N()
+	terms := make([]term, 0)
+
+	pos := 0
+	skipSpace := func() {
+		for pos < len(s) && (s[pos] == ' ' || s[pos] == '\t' || s[pos] == '\n') {
+			pos++
+		}
+	}
+	readDigits := func() string {
+		start := pos
+		for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+			pos++
+		}
+		return s[start:pos]
+	}
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s at offset %d in %q", ErrSyntax, fmt.Sprintf(format, args...), pos, s)
+	}
+
+	skipSpace()
+	if pos == len(s) {
+		return nil, fail("empty input")
+	}
+
+	for pos < len(s) {
+		// Operator between terms, then any number of unary signs
+		negative := false
+		if len(terms) > 0 {
+			if s[pos] != '+' && s[pos] != '-' {
+				return nil, fail("expected + or -")
+			}
+			negative = s[pos] == '-'
+			pos++
+			skipSpace()
+		}
+		for pos < len(s) && (s[pos] == '+' || s[pos] == '-') {
+			negative = negative != (s[pos] == '-')
+			pos++
+			skipSpace()
+		}
+
+		coeff := uint64(1)
+		digits := readDigits()
+		if digits != "" {
+			var err error
+			coeff, err = strconv.ParseUint(digits, 10, 63)
+			if err != nil {
+				return nil, fail("coefficient %s out of range", digits)
+			}
+			skipSpace()
+			if pos < len(s) && s[pos] == '*' {
+				pos++
+				skipSpace()
+				if pos == len(s) || (s[pos] != 'x' && s[pos] != 'X') {
+					return nil, fail("expected x after *")
+				}
+			}
+		}
+
+		exp := uint64(0)
+		if pos < len(s) && (s[pos] == 'x' || s[pos] == 'X') {
+			pos++
+			exp = 1
+			skipSpace()
+			if pos < len(s) && s[pos] == '^' {
+				pos++
+				skipSpace()
+				expDigits := readDigits()
+				if expDigits == "" {
+					return nil, fail("expected exponent after ^")
+				}
+				var err error
+				exp, err = strconv.ParseUint(expDigits, 10, 63)
+				if err != nil {
+					return nil, fail("exponent %s out of range", expDigits)
+				}
+			}
+		} else if digits == "" {
+			return nil, fail("expected a coefficient or x")
+		}
+
+		// x^N = -1
+		wraps, exp := bits.Div64(0, exp, uint64(n))
+		if wraps%2 == 1 {
+			negative = !negative
+		}
+
+		c := int64(coeff)
+		if negative {
+			c = -c
+		}
+		terms = append(terms, term{c, int(exp)})
+		skipSpace()
+	}
+	return terms, nil
+}
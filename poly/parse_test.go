@@ -0,0 +1,76 @@
+package poly
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParsePolyRoundTrip(t *testing.T) {
+	p := NewRandomPoly()
+	for _, s := range []string{p.String(), p.CoeffString()} {
+		parsed, err := ParsePoly(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(p) {
+			t.Errorf("round trip of %.40q... failed", s)
+		}
+	}
+
+	zero, err := ParsePoly(NewPoly().String())
+	if err != nil || !zero.Equals(NewPoly()) {
+		t.Errorf("zero polynomial not parsed: %v", err)
+	}
+}
+
+func TestParsePolyQRoundTrip(t *testing.T) {
+	p := NewRandomPolyQ(nil)
+	for _, s := range []string{p.String(), p.CoeffString(), p.NonQ().WithCenteredModulo().String()} {
+		parsed, err := ParsePolyQ(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(p) {
+			t.Errorf("round trip of %.40q... failed", s)
+		}
+	}
+}
+
+func TestParsePolyExpressions(t *testing.T) {
+	cases := map[string]Poly{
+		"3 + 2*x + x^5":  NewPolyFromCoeffs(3, 2, 0, 0, 0, 1),
+		"-x^2 - 1":       NewPolyFromCoeffs(-1, 0, -1),
+		"2x^3":           NewPolyFromCoeffs(0, 0, 0, 2),
+		"1 + -3 * X":     NewPolyFromCoeffs(1, -3),
+		"x + x - - x":    NewPolyFromCoeffs(0, 3),
+		"x^128":          NewPolyFromCoeffs(-1),
+		"5*x^257 + x^1":  NewPolyFromCoeffs(0, 6),
+		"  7  ":          NewPolyFromCoeffs(7),
+		"[1, -2, 3]":     NewPolyFromCoeffs(1, -2, 3),
+		"0 + 0*x + 0x^2": NewPoly(),
+	}
+	for s, expected := range cases {
+		parsed, err := ParsePoly(s)
+		if err != nil {
+			t.Errorf("%q: %v", s, err)
+		} else if !parsed.Equals(expected) {
+			t.Errorf("%q parsed as %v", s, parsed)
+		}
+	}
+
+	q, err := ParsePolyQ("-1 - x^129")
+	if err != nil || !q.Equals(NewPolyQFromCoeffs(-1, 1)) {
+		t.Errorf("centered PolyQ not reduced: %v", err)
+	}
+}
+
+func TestParsePolyErrors(t *testing.T) {
+	for _, s := range []string{"", "x^", "2*", "3 4", "x^-1", "1 +", "y", "2**x", "9223372036854775808", "9223372036854775807 + 1"} {
+		if _, err := ParsePoly(s); !errors.Is(err, ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", s, err)
+		}
+	}
+	if _, err := ParsePolyQ("[1,2"); err == nil {
+		t.Error("malformed coefficient list accepted")
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"strings"
 
 	"github.com/isri-pqc/latticehelper"
-	"github.com/raszia/gotiny"
+	"github.com/isri-pqc/latticehelper/internal/compress"
 	"github.com/tuneinsight/lattigo/v5/utils/sampling"
 )
 
@@ -47,17 +47,19 @@ func NewRandomPoly() Poly {
 	return ret
 }
 
+// Plain []int64 so the encoding does not go through Poly.MarshalBinary
 func (coeffs Poly) Serialize() []byte {
-	return gotiny.MarshalCompress(&coeffs)
+	raw := []int64(coeffs)
+	return compress.Marshal(&raw)
 }
 
 func DeserializePoly(data []byte) Poly {
-	var p Poly
-	n := gotiny.UnmarshalCompress(data, &p)
+	var raw []int64
+	n := compress.Unmarshal(data, &raw)
 	if n == 0 {
 		panic("failed to deserialize")
 	}
-	return p
+	return Poly(raw)
 }
 
 func (coeffs Poly) CoeffString() string {
@@ -146,6 +148,30 @@ func (coeffs Poly) LowBits(alpha int64) Poly {
 	return ret
 }
 
+func (coeffs Poly) HighBits(alpha int64) Poly {
+	ret := make(Poly, len(coeffs))
+
+	for i, coeff := range coeffs {
+		ret[i] = highBits(coeff, alpha, latticehelper.MainRing.Modulus().Int64())
+	}
+
+	return ret
+}
+
+// Largest absolute value of the integer coefficients, without reducing mod q
+func (coeffs Poly) InfiniteNorm() int64 {
+	max := int64(0)
+	for _, coeff := range coeffs {
+		if coeff < 0 {
+			coeff = -coeff
+		}
+		if coeff > max {
+			max = coeff
+		}
+	}
+	return max
+}
+
 func (coeffs Poly) Length() int {
 	return len(coeffs)
 }
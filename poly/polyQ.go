@@ -229,6 +229,27 @@ func (poly PolyQ) HighBits(alpha int64) PolyQ {
 	return PolyQ{*ret}
 }
 
+func (poly PolyQ) LowBits(alpha int64) PolyQ {
+	ret := poly.CopyNew()
+	q := latticehelper.MainRing.Modulus().Int64()
+
+	for i, coeff := range poly.Coeffs[latticehelper.MainRing.Level()] {
+		ret.Coeffs[latticehelper.MainRing.Level()][i] = uint64(latticehelper.PositiveMod(lowBits(int64(coeff), alpha, q), q))
+	}
+
+	return PolyQ{*ret}
+}
+
+// Same convention as Poly.CheckNormBound, true if some centered coefficient reaches bound
+func (poly PolyQ) CheckNormBound(bound int64) bool {
+	for _, coeff := range poly.Listize() {
+		if checkNormBound(coeff, bound, latticehelper.MainRing.Modulus().Int64()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (poly PolyQ) Neg() PolyQ {
 	retPoly := NewPolyQ()
 	latticehelper.MainRing.Neg(poly.Poly, retPoly.Poly)
@@ -306,6 +327,19 @@ func (poly PolyQ) AddedToFirstCoeff(input int64) PolyQ {
 	return PolyQ{retPoly}
 }
 
+// Unlike ring.Ring.Equal, this does not reduce the operands in place,
+// so it is safe to call on polynomials shared between goroutines
 func (poly PolyQ) Equals(other PolyQ) bool {
-	return latticehelper.MainRing.Equal(poly.Poly, other.Poly)
+	for i, s := range latticehelper.MainRing.SubRings[:latticehelper.MainRing.Level()+1] {
+		a, b := poly.Coeffs[i], other.Coeffs[i]
+		if len(a) != len(b) {
+			return false
+		}
+		for j := range a {
+			if a[j]%s.Modulus != b[j]%s.Modulus {
+				return false
+			}
+		}
+	}
+	return true
 }
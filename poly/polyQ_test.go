@@ -62,3 +62,52 @@ func TestPolyQScale(t *testing.T) {
 		t.Error("Poly scale failed")
 	}
 }
+
+func TestPolyQCoeffMul(t *testing.T) {
+	result := NewPolyQFromCoeffs(1, 2, 3, 4).CoeffMul(NewPolyQFromCoeffs(5, 6, -7))
+	expected := NewPolyQFromCoeffs(5, 12, -21)
+	if !result.Equals(expected) {
+		t.Error("Poly coefficient-wise multiplication failed")
+	}
+
+	// b ∘ (b - 1) = 0 exactly for binary b
+	ones := NewPolyQFromCoeffs(1, 1, 1, 1)
+	b := NewPolyQFromCoeffs(1, 0, 1, 1)
+	if !b.CoeffMul(b.Sub(ones)).Equals(NewPolyQ()) {
+		t.Error("binary constraint failed")
+	}
+	nonBinary := NewPolyQFromCoeffs(1, 2)
+	if nonBinary.CoeffMul(nonBinary.Sub(ones)).Equals(NewPolyQ()) {
+		t.Error("binary constraint accepted a non-binary polynomial")
+	}
+}
+
+func TestPolyQNTTSlots(t *testing.T) {
+	a, b := NewRandomPolyQ(nil), NewRandomPolyQ(nil)
+
+	if !NewPolyQFromNTTSlots(a.NTTSlots()...).Equals(a) {
+		t.Error("NTT slots round trip failed")
+	}
+
+	// Ring multiplication is slot-wise multiplication
+	slotwise := NewPolyQFromNTTSlots(NewPolyQFromCoeffs(a.NTTSlots()...).CoeffMul(NewPolyQFromCoeffs(b.NTTSlots()...)).Listize()...)
+	if !slotwise.Equals(a.Mul(b)) {
+		t.Error("slot-wise product differs from ring multiplication")
+	}
+}
+
+func TestPolyQDecomposeRecombines(t *testing.T) {
+	alpha := int64(256)
+	p := NewRandomPolyQ(nil)
+
+	if !p.HighBits(alpha).ScaledByInt(alpha).Add(p.LowBits(alpha)).Equals(p) {
+		t.Error("HighBits * alpha + LowBits differs from the input")
+	}
+}
+
+func TestPolyQCheckNormBound(t *testing.T) {
+	p := NewPolyQFromCoeffs(3, -5)
+	if p.CheckNormBound(6) || !p.CheckNormBound(5) {
+		t.Error("PolyQ norm bound check failed")
+	}
+}
@@ -70,3 +70,29 @@ func TestPolyScale(t *testing.T) {
 		t.Error("Poly scale failed")
 	}
 }
+
+func TestPolyCoeffMul(t *testing.T) {
+	result := NewPolyFromCoeffs(1, -2, 3).CoeffMul(NewPolyFromCoeffs(4, 5))
+	expected := NewPolyFromCoeffs(4, -10)
+	if !result.Equals(expected) {
+		t.Error("Poly coefficient-wise multiplication failed")
+	}
+}
+
+func TestPolyDecomposeMatchesPolyQ(t *testing.T) {
+	alpha := int64(256)
+	p := NewPolyFromCoeffs(1, -2, 1<<20, -(1 << 30), 12345)
+
+	if !p.HighBits(alpha).Q().Equals(p.Q().HighBits(alpha)) {
+		t.Error("Poly HighBits differs from PolyQ HighBits")
+	}
+	if !p.LowBits(alpha).Q().Equals(p.Q().LowBits(alpha)) {
+		t.Error("Poly LowBits differs from PolyQ LowBits")
+	}
+}
+
+func TestPolyInfiniteNorm(t *testing.T) {
+	if n := NewPolyFromCoeffs(3, -7, 5).InfiniteNorm(); n != 7 {
+		t.Errorf("Expected 7 but got %d", n)
+	}
+}
@@ -0,0 +1,97 @@
+package vector
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Runs every operation from many goroutines at once and compares the
+// results with a sequential run. Meant for `go test -race`.
+func runConcurrently(t *testing.T, ops map[string]func() any) {
+	expected := make(map[string]any, len(ops))
+	for name, op := range ops {
+		expected[name] = op()
+	}
+
+	var wg sync.WaitGroup
+	for name, op := range ops {
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(name string, op func() any) {
+				defer wg.Done()
+				if result := op(); !reflect.DeepEqual(result, expected[name]) {
+					t.Errorf("%s: concurrent result differs from sequential one", name)
+				}
+			}(name, op)
+		}
+	}
+	wg.Wait()
+}
+
+func copyPolyQVector(vec PolyQVector) PolyQVector {
+	ret := make(PolyQVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = poly.PolyQ{Poly: *p.CopyNew()}
+	}
+	return ret
+}
+
+func TestPolyQVectorConcurrentReaders(t *testing.T) {
+	a := NewRandomPolyQVector(nil, 4)
+	b := NewRandomPolyQVectorWithMaxInfNorm(4, 1000)
+	p := poly.NewRandomPolyQ(nil)
+	aCopy, bCopy := copyPolyQVector(a), copyPolyQVector(b)
+
+	runConcurrently(t, map[string]func() any{
+		"DotProduct":    func() any { return a.DotProduct(b) },
+		"OuterProduct":  func() any { return a.OuterProduct(b) },
+		"ScaledByPolyQ": func() any { return a.ScaledByPolyQ(p) },
+		"ScaledByInt":   func() any { return a.ScaledByInt(3) },
+		"Add":           func() any { return a.Add(b) },
+		"Sub":           func() any { return a.Sub(b) },
+		"Concat":        func() any { return a.Concat(b) },
+		"HighBits":      func() any { return a.HighBits(1 << 20) },
+		"Power2Round": func() any {
+			r1, r0 := a.Power2Round(13)
+			return []PolyQVector{r1, r0}
+		},
+		"InfiniteNorm":  func() any { return b.InfiniteNorm() },
+		"SecondNorm":    func() any { return b.SecondNorm() },
+		"CanonicalNorm": func() any { return b.CanonicalNorm() },
+		"NonQ":          func() any { return a.NonQ() },
+		"Listize":       func() any { return a.Listize() },
+		"String":        func() any { return a.String() },
+		"Serialize":     func() any { return a.Serialize() },
+		"Equals":        func() any { return a.Equals(aCopy) },
+	})
+
+	if !a.Equals(aCopy) || !b.Equals(bCopy) {
+		t.Error("Read-only operations modified their inputs")
+	}
+}
+
+func TestPolyVectorConcurrentReaders(t *testing.T) {
+	a := NewPolyVectorFromCoeffs([][]int64{{1, 2, 3}, {-4, 5}})
+	b := NewPolyVectorFromCoeffs([][]int64{{7, -8}, {9, 10, 11}})
+	aCopy := NewPolyVectorFromCoeffs([][]int64{{1, 2, 3}, {-4, 5}})
+
+	runConcurrently(t, map[string]func() any{
+		"DotProduct":     func() any { return a.DotProduct(b) },
+		"ScaledByPoly":   func() any { return a.ScaledByPoly(b[0]) },
+		"Add":            func() any { return a.Add(b) },
+		"Sub":            func() any { return a.Sub(b) },
+		"LowBits":        func() any { return a.LowBits(1 << 10) },
+		"CheckNormBound": func() any { return a.CheckNormBound(4) },
+		"CanonicalNorm":  func() any { return a.CanonicalNorm() },
+		"Q":              func() any { return a.Q() },
+		"String":         func() any { return a.String() },
+		"Equals":         func() any { return a.Equals(b) },
+	})
+
+	if !a.Equals(aCopy) {
+		t.Error("Read-only operations modified their inputs")
+	}
+}
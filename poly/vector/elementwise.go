@@ -0,0 +1,105 @@
+package vector
+
+import (
+	"log"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Entry-wise ring product vec[i] * other[i]
+func (vec PolyQVector) Hadamard(inputPolyQVector PolyQVector) PolyQVector {
+	if inputPolyQVector.Length() != vec.Length() {
+		log.Panic("Hadamard: two vectors don't have the same length.")
+	}
+
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	vecNTT := r.NewPoly()
+	inputNTT := r.NewPoly()
+
+	ret := make(PolyQVector, vec.Length())
+	for i := range vec {
+		r.NTT(vec[i].Poly, vecNTT)
+		r.NTT(inputPolyQVector[i].Poly, inputNTT)
+
+		ret[i] = poly.NewPolyQ()
+		r.MulCoeffsBarrett(vecNTT, inputNTT, ret[i].Poly)
+		r.INTT(ret[i].Poly, ret[i].Poly)
+	}
+	return ret
+}
+
+// Entry-wise coefficient-wise product, see poly.PolyQ.CoeffMul
+func (vec PolyQVector) CoeffMul(inputPolyQVector PolyQVector) PolyQVector {
+	if inputPolyQVector.Length() != vec.Length() {
+		log.Panic("CoeffMul: two vectors don't have the same length.")
+	}
+
+	ret := make(PolyQVector, vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].CoeffMul(inputPolyQVector[i])
+	}
+	return ret
+}
+
+// Sum of all entries
+func (vec PolyQVector) Sum() poly.PolyQ {
+	ret := poly.NewPolyQ()
+	for _, p := range vec {
+		latticehelper.MainRing.Add(ret.Poly, p.Poly, ret.Poly)
+	}
+	return ret
+}
+
+// NTT slots of every entry, see poly.PolyQ.NTTSlots
+func (vec PolyQVector) NTTSlots() [][]int64 {
+	ret := make([][]int64, vec.Length())
+	for i, p := range vec {
+		ret[i] = p.NTTSlots()
+	}
+	return ret
+}
+
+func NewPolyQVectorFromNTTSlots(slots [][]int64) PolyQVector {
+	ret := make(PolyQVector, len(slots))
+	for i, s := range slots {
+		ret[i] = poly.NewPolyQFromNTTSlots(s...)
+	}
+	return ret
+}
+
+// Entry-wise ring product vec[i] * other[i]
+func (vec PolyVector) Hadamard(inputPolyVector PolyVector) PolyVector {
+	if inputPolyVector.Length() != vec.Length() {
+		log.Panic("Hadamard: two vectors don't have the same length.")
+	}
+
+	ret := make(PolyVector, vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].Mul(inputPolyVector[i])
+	}
+	return ret
+}
+
+// Entry-wise coefficient-wise product, see poly.Poly.CoeffMul
+func (vec PolyVector) CoeffMul(inputPolyVector PolyVector) PolyVector {
+	if inputPolyVector.Length() != vec.Length() {
+		log.Panic("CoeffMul: two vectors don't have the same length.")
+	}
+
+	ret := make(PolyVector, vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].CoeffMul(inputPolyVector[i])
+	}
+	return ret
+}
+
+// Sum of all entries
+func (vec PolyVector) Sum() poly.Poly {
+	ret := poly.NewPoly()
+	for _, p := range vec {
+		ret = ret.Add(p)
+	}
+	return ret
+}
@@ -0,0 +1,80 @@
+package vector
+
+import (
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+// Versioned encoding with the ring fingerprint, see package wire.
+// Serialize keeps the older format.
+func (vec PolyQVector) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, wire.HeaderSize+vec.Length()*wire.PolyQSize())
+	b = wire.AppendHeader(b, wire.TagPolyQVector, 1, vec.Length())
+	for i, p := range vec {
+		if err := wire.CheckPolyQ(p.Coeffs); err != nil {
+			return nil, fmt.Errorf("MarshalBinary: entry %d: %w", i, err)
+		}
+		b = wire.AppendPolyQ(b, p.Coeffs)
+	}
+	return b, nil
+}
+
+func (vec *PolyQVector) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPolyQVector)
+	if err != nil {
+		return err
+	}
+	if rows != 1 {
+		return fmt.Errorf("%w: PolyQVector with dimensions %dx%d", wire.ErrMalformed, rows, cols)
+	}
+	if err := wire.CheckPayload(payload, rows, cols, wire.PolyQSize()); err != nil {
+		return err
+	}
+
+	ret := make(PolyQVector, cols)
+	for i := range ret {
+		ret[i] = poly.NewPolyQ()
+		if payload, err = wire.ReadPolyQ(payload, ret[i].Coeffs); err != nil {
+			return err
+		}
+	}
+	*vec = ret
+	return nil
+}
+
+func (vec PolyVector) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, wire.HeaderSize+vec.Length()*wire.PolySize())
+	b = wire.AppendHeader(b, wire.TagPolyVector, 1, vec.Length())
+	for i, p := range vec {
+		if p.Length() != wire.PolySize()/8 {
+			return nil, fmt.Errorf("MarshalBinary: entry %d has %d coefficients", i, p.Length())
+		}
+		b = wire.AppendPoly(b, p)
+	}
+	return b, nil
+}
+
+func (vec *PolyVector) UnmarshalBinary(data []byte) error {
+	rows, cols, payload, err := wire.ParseHeader(data, wire.TagPolyVector)
+	if err != nil {
+		return err
+	}
+	if rows != 1 {
+		return fmt.Errorf("%w: PolyVector with dimensions %dx%d", wire.ErrMalformed, rows, cols)
+	}
+	if err := wire.CheckPayload(payload, rows, cols, wire.PolySize()); err != nil {
+		return err
+	}
+
+	ret := make(PolyVector, cols)
+	for i := range ret {
+		ret[i] = poly.NewPoly()
+		if payload, err = wire.ReadPoly(payload, ret[i]); err != nil {
+			return err
+		}
+	}
+	*vec = ret
+	return nil
+}
@@ -0,0 +1,90 @@
+package vector
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQVectorMarshalBinary(t *testing.T) {
+	for _, length := range []int{0, 1, 5} {
+		v := NewRandomPolyQVector(nil, length)
+
+		b, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded PolyQVector
+		if err := decoded.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Length() != length || !decoded.Equals(v) {
+			t.Errorf("PolyQVector of length %d: binary round trip failed", length)
+		}
+	}
+}
+
+func TestPolyVectorMarshalBinary(t *testing.T) {
+	v := NewRandomPolyVector(3)
+
+	b, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded PolyVector
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(v) {
+		t.Error("PolyVector binary round trip failed")
+	}
+
+	var wrongType PolyQVector
+	if err := wrongType.UnmarshalBinary(b); !errors.Is(err, wire.ErrType) {
+		t.Errorf("PolyVector encoding decoded as PolyQVector: %v", err)
+	}
+}
+
+func FuzzPolyQVectorUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPolyQVector(nil, 2).MarshalBinary()
+	f.Add(b)
+	b, _ = PolyQVector{}.MarshalBinary()
+	f.Add(b)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v PolyQVector
+		if v.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded PolyQVector does not re-encode to its input")
+		}
+	})
+}
+
+func FuzzPolyVectorUnmarshalBinary(f *testing.F) {
+	b, _ := NewRandomPolyVector(2).MarshalBinary()
+	f.Add(b)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v PolyVector
+		if v.UnmarshalBinary(data) != nil {
+			return
+		}
+		encoded, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Error("decoded PolyVector does not re-encode to its input")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package vector
+
+import (
+	"github.com/isri-pqc/latticehelper/internal/format"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Formats vec as a column with centered coefficients and
+// poly.DefaultFormatOptions, see poly.Format
+func Format[T PolyVector | PolyQVector](vec T, style poly.Style) string {
+	return FormatWithOptions(vec, style, poly.DefaultFormatOptions)
+}
+
+// Entries longer than opts.MaxRows are shortened with an ellipsis
+func FormatWithOptions[T PolyVector | PolyQVector](vec T, style poly.Style, opts poly.FormatOptions) string {
+	var entry func(i int) string
+	switch v := any(vec).(type) {
+	case PolyVector:
+		entry = func(i int) string { return poly.FormatWithOptions(v[i], style, opts) }
+	case PolyQVector:
+		entry = func(i int) string { return poly.FormatWithOptions(v[i], style, opts) }
+	}
+	return format.Column(len(vec), entry, int(style), opts.MaxRows)
+}
@@ -0,0 +1,26 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestFormatVector(t *testing.T) {
+	vec := NewPolyVectorFromCoeffs([][]int64{{1}, {0, 1}, {-2}})
+
+	cases := map[poly.Style]string{
+		poly.LaTeX:    "\\begin{pmatrix}\n  1 \\\\\n  x \\\\\n  -2\n\\end{pmatrix}",
+		poly.Markdown: "|  | 0 |\n| --- | --- |\n| 0 | `1` |\n| 1 | `x` |\n| 2 | `-2` |",
+		poly.Compact:  "(1, x, -2)",
+	}
+	for style, expected := range cases {
+		if s := Format(vec.Q(), style); s != expected {
+			t.Errorf("%v: got\n%s\nwant\n%s", style, s, expected)
+		}
+	}
+
+	if s := FormatWithOptions(vec, poly.Markdown, poly.FormatOptions{MaxRows: 2}); s != "|  | 0 |\n| --- | --- |\n| 0 | `1` |\n| ... | ... |\n| 2 | `-2` |" {
+		t.Errorf("truncated table:\n%s", s)
+	}
+}
@@ -0,0 +1,203 @@
+package vector
+
+import (
+	"log"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Vector over either ring. PolyVector and PolyQVector convert to and from
+// Vector[poly.Poly] and Vector[poly.PolyQ] directly, e.g.
+// vector.Vector[poly.PolyQ](v). The concrete types are faster, use this
+// for code that should run over both rings.
+type Vector[T poly.RingElement[T]] []T
+
+// newElement is poly.NewPolyFromCoeffs or poly.NewPolyQFromCoeffs
+func NewZeroVector[T poly.RingElement[T]](newElement func(...int64) T, length int) Vector[T] {
+	vec := make(Vector[T], length)
+	for i := range vec {
+		vec[i] = newElement()
+	}
+	return vec
+}
+
+func (vec Vector[T]) Length() int {
+	return len(vec)
+}
+
+func (vec Vector[T]) Listize() []int64 {
+	ret := make([]int64, 0)
+	for _, p := range vec {
+		ret = append(ret, p.Listize()...)
+	}
+	return ret
+}
+
+func (vec Vector[T]) CoeffString() string {
+	coeffStrings := make([]string, vec.Length())
+	for i, p := range vec {
+		coeffStrings[i] = p.CoeffString()
+	}
+	return "[" + strings.Join(coeffStrings, ",") + "]"
+}
+
+func (vec Vector[T]) String() string {
+	polyStrings := make([]string, vec.Length())
+	for i, p := range vec {
+		polyStrings[i] = p.String()
+	}
+	return "Vector{" + strings.Join(polyStrings, ", ") + "}"
+}
+
+func (vec Vector[T]) Add(inputVector Vector[T]) Vector[T] {
+	if inputVector.Length() != vec.Length() {
+		log.Panic("Add: two vectors don't have the same length.")
+	}
+
+	ret := make(Vector[T], vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].Add(inputVector[i])
+	}
+	return ret
+}
+
+func (vec Vector[T]) Sub(inputVector Vector[T]) Vector[T] {
+	if inputVector.Length() != vec.Length() {
+		log.Panic("Sub: two vectors don't have the same length.")
+	}
+
+	ret := make(Vector[T], vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].Sub(inputVector[i])
+	}
+	return ret
+}
+
+func (vec Vector[T]) Neg() Vector[T] {
+	ret := make(Vector[T], vec.Length())
+	for i, p := range vec {
+		ret[i] = p.Neg()
+	}
+	return ret
+}
+
+func (vec Vector[T]) ScaledBy(input T) Vector[T] {
+	ret := make(Vector[T], vec.Length())
+	for i, p := range vec {
+		ret[i] = p.Mul(input)
+	}
+	return ret
+}
+
+func (vec Vector[T]) ScaledByInt(input int64) Vector[T] {
+	ret := make(Vector[T], vec.Length())
+	for i, p := range vec {
+		ret[i] = p.ScaledByInt(input)
+	}
+	return ret
+}
+
+func (vec Vector[T]) Concat(inputVector Vector[T]) Vector[T] {
+	ret := make(Vector[T], 0, vec.Length()+inputVector.Length())
+	ret = append(ret, vec...)
+	return append(ret, inputVector...)
+}
+
+// Vectors must not be empty, there is no zero element to return otherwise
+func (vec Vector[T]) DotProduct(inputVector Vector[T]) T {
+	if inputVector.Length() != vec.Length() {
+		log.Panic("DotProduct: two vectors don't have the same length.")
+	}
+	if vec.Length() == 0 {
+		log.Panic("DotProduct: empty vectors")
+	}
+
+	ret := vec[0].Mul(inputVector[0])
+	for i := 1; i < vec.Length(); i++ {
+		ret = ret.Add(vec[i].Mul(inputVector[i]))
+	}
+	return ret
+}
+
+func (vec Vector[T]) Hadamard(inputVector Vector[T]) Vector[T] {
+	if inputVector.Length() != vec.Length() {
+		log.Panic("Hadamard: two vectors don't have the same length.")
+	}
+
+	ret := make(Vector[T], vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].Mul(inputVector[i])
+	}
+	return ret
+}
+
+func (vec Vector[T]) CoeffMul(inputVector Vector[T]) Vector[T] {
+	if inputVector.Length() != vec.Length() {
+		log.Panic("CoeffMul: two vectors don't have the same length.")
+	}
+
+	ret := make(Vector[T], vec.Length())
+	for i := range vec {
+		ret[i] = vec[i].CoeffMul(inputVector[i])
+	}
+	return ret
+}
+
+// Vector must not be empty
+func (vec Vector[T]) Sum() T {
+	if vec.Length() == 0 {
+		log.Panic("Sum: empty vector")
+	}
+
+	ret := vec[0]
+	for _, p := range vec[1:] {
+		ret = ret.Add(p)
+	}
+	return ret
+}
+
+func (vec Vector[T]) HighBits(alpha int64) Vector[T] {
+	ret := make(Vector[T], vec.Length())
+	for i, p := range vec {
+		ret[i] = p.HighBits(alpha)
+	}
+	return ret
+}
+
+func (vec Vector[T]) LowBits(alpha int64) Vector[T] {
+	ret := make(Vector[T], vec.Length())
+	for i, p := range vec {
+		ret[i] = p.LowBits(alpha)
+	}
+	return ret
+}
+
+func (vec Vector[T]) InfiniteNorm() int64 {
+	ret := int64(0)
+	for _, p := range vec {
+		ret = max(ret, p.InfiniteNorm())
+	}
+	return ret
+}
+
+func (vec Vector[T]) CheckNormBound(bound int64) bool {
+	for _, p := range vec {
+		if p.CheckNormBound(bound) {
+			return true
+		}
+	}
+	return false
+}
+
+func (vec Vector[T]) Equals(other Vector[T]) bool {
+	if vec.Length() != other.Length() {
+		return false
+	}
+	for i := range vec {
+		if !vec[i].Equals(other[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package vector
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestGenericVectorMatchesPolyQVector(t *testing.T) {
+	a := NewRandomPolyQVector(nil, 3)
+	b := NewRandomPolyQVector(nil, 3)
+	ga, gb := Vector[poly.PolyQ](a), Vector[poly.PolyQ](b)
+
+	if !ga.DotProduct(gb).Equals(a.DotProduct(b)) {
+		t.Error("DotProduct differs")
+	}
+	if !PolyQVector(ga.Add(gb)).Equals(a.Add(b)) || !PolyQVector(ga.Sub(gb)).Equals(a.Sub(b)) {
+		t.Error("Add or Sub differs")
+	}
+	if !PolyQVector(ga.Hadamard(gb)).Equals(a.Hadamard(b)) || !ga.Sum().Equals(a.Sum()) {
+		t.Error("Hadamard or Sum differs")
+	}
+	if ga.InfiniteNorm() != a.InfiniteNorm() || ga.CoeffString() != a.CoeffString() {
+		t.Error("InfiniteNorm or CoeffString differs")
+	}
+}
+
+func TestGenericVectorMatchesPolyVector(t *testing.T) {
+	a := PolyVector{poly.NewPolyFromCoeffs(1, -2), poly.NewPolyFromCoeffs(3)}
+	b := PolyVector{poly.NewPolyFromCoeffs(0, 5), poly.NewPolyFromCoeffs(-4, 1)}
+	ga, gb := Vector[poly.Poly](a), Vector[poly.Poly](b)
+
+	if !ga.DotProduct(gb).Equals(a.DotProduct(b)) {
+		t.Error("DotProduct differs")
+	}
+	if !PolyVector(ga.ScaledByInt(3)).Equals(a.ScaledByInt(3)) {
+		t.Error("ScaledByInt differs")
+	}
+	if !PolyVector(ga.LowBits(256)).Equals(a.LowBits(256)) || ga.InfiniteNorm() != 3 {
+		t.Error("LowBits or InfiniteNorm differs")
+	}
+}
+
+func TestNewZeroVector(t *testing.T) {
+	if !NewZeroVector(poly.NewPolyQFromCoeffs, 2).Equals(Vector[poly.PolyQ](NewZeroPolyQVector(2))) {
+		t.Error("zero PolyQ vector differs")
+	}
+	if !NewZeroVector(poly.NewPolyFromCoeffs, 2).Equals(Vector[poly.Poly](NewZeroPolyVector(2))) {
+		t.Error("zero Poly vector differs")
+	}
+}
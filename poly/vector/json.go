@@ -0,0 +1,109 @@
+package vector
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func (vec PolyQVector) MarshalJSON() ([]byte, error) {
+	return vec.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+func (vec PolyQVector) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	coeffs := make([][]int64, vec.Length())
+	for i, p := range vec {
+		if opts.Centered {
+			coeffs[i] = p.NonQ().WithCenteredModulo()
+		} else {
+			coeffs[i] = p.Listize()
+		}
+	}
+	return wire.MarshalJSON(wire.TagPolyQVector, coeffs, opts)
+}
+
+// Accepts the plain array and the metadata form, see PolyQ.UnmarshalJSON
+func (vec *PolyQVector) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPolyQVector)
+	if err != nil {
+		return err
+	}
+	return vec.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (vec PolyQVector) MarshalText() ([]byte, error) {
+	return []byte(vec.CoeffString()), nil
+}
+
+// Parses the CoeffString form
+func (vec *PolyQVector) UnmarshalText(text []byte) error {
+	entries, err := splitJSONArray(text)
+	if err != nil {
+		return err
+	}
+
+	ret := make(PolyQVector, len(entries))
+	for i, entry := range entries {
+		if err := ret[i].UnmarshalText(entry); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+	*vec = ret
+	return nil
+}
+
+func (vec PolyVector) MarshalJSON() ([]byte, error) {
+	return vec.MarshalJSONWithOptions(wire.JSONOptions{})
+}
+
+// Poly coefficients are plain integers, opts.Centered does not apply
+func (vec PolyVector) MarshalJSONWithOptions(opts wire.JSONOptions) ([]byte, error) {
+	coeffs := make([][]int64, vec.Length())
+	for i, p := range vec {
+		coeffs[i] = p
+	}
+	return wire.MarshalJSON(wire.TagPolyVector, coeffs, opts)
+}
+
+func (vec *PolyVector) UnmarshalJSON(data []byte) error {
+	raw, err := wire.UnwrapJSON(data, wire.TagPolyVector)
+	if err != nil {
+		return err
+	}
+	return vec.UnmarshalText(raw)
+}
+
+// Same as CoeffString
+func (vec PolyVector) MarshalText() ([]byte, error) {
+	return []byte(vec.CoeffString()), nil
+}
+
+// Parses the CoeffString form
+func (vec *PolyVector) UnmarshalText(text []byte) error {
+	entries, err := splitJSONArray(text)
+	if err != nil {
+		return err
+	}
+
+	ret := make(PolyVector, len(entries))
+	for i, entry := range entries {
+		if err := ret[i].UnmarshalText(entry); err != nil {
+			return fmt.Errorf("entry %d: %w", i, err)
+		}
+	}
+	*vec = ret
+	return nil
+}
+
+func splitJSONArray(text []byte) ([]json.RawMessage, error) {
+	var entries []json.RawMessage
+	if err := json.Unmarshal(text, &entries); err != nil {
+		return nil, fmt.Errorf("%w: %v", wire.ErrMalformed, err)
+	}
+	if entries == nil {
+		return nil, fmt.Errorf("%w: expected an array", wire.ErrMalformed)
+	}
+	return entries, nil
+}
@@ -0,0 +1,65 @@
+package vector
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/wire"
+)
+
+func TestPolyQVectorJSONRoundTrip(t *testing.T) {
+	v := NewRandomPolyQVector(nil, 3)
+
+	for _, opts := range []wire.JSONOptions{{}, {Centered: true, Metadata: true}} {
+		b, err := v.MarshalJSONWithOptions(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded PolyQVector
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Length() != 3 || !decoded.Equals(v) {
+			t.Errorf("%+v: JSON round trip failed", opts)
+		}
+	}
+
+	var decoded PolyQVector
+	if err := decoded.UnmarshalText([]byte(v.CoeffString())); err != nil || !decoded.Equals(v) {
+		t.Errorf("CoeffString not parsed back: %v", err)
+	}
+}
+
+func TestPolyQVectorJSONInStruct(t *testing.T) {
+	type transcript struct {
+		Commitment PolyQVector
+		Response   PolyVector
+	}
+	in := transcript{NewRandomPolyQVector(nil, 2), NewRandomPolyVector(2)}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out transcript
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Commitment.Equals(in.Commitment) || !out.Response.Equals(in.Response) {
+		t.Error("struct JSON round trip failed")
+	}
+}
+
+func TestPolyVectorJSONErrors(t *testing.T) {
+	var v PolyVector
+	b, _ := NewRandomPolyQVector(nil, 1).MarshalJSONWithOptions(wire.JSONOptions{Metadata: true})
+	if err := json.Unmarshal(b, &v); !errors.Is(err, wire.ErrType) {
+		t.Errorf("PolyQVector document decoded as PolyVector: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`[[1],"x"]`), &v); !errors.Is(err, wire.ErrMalformed) {
+		t.Errorf("malformed entry accepted: %v", err)
+	}
+}
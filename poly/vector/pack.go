@@ -0,0 +1,90 @@
+package vector
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Same checks as the polynomial level, done before sizing anything
+func checkWidth(name string, d int) error {
+	if d < 1 || d > 64 {
+		return fmt.Errorf("%s: %w: bit width %d is not in [1, 64]", name, poly.ErrPackRange, d)
+	}
+	return nil
+}
+
+func checkRange(name string, lo, hi int64) error {
+	if lo >= hi {
+		return fmt.Errorf("%s: %w: range [%d, %d] has less than two values", name, poly.ErrPackRange, lo, hi)
+	}
+	return nil
+}
+
+// Concatenation of PolyQ.PackBits of every entry
+func (vec PolyQVector) PackBits(d int) ([]byte, error) {
+	if err := checkWidth("PackBits", d); err != nil {
+		return nil, err
+	}
+	ret := make([]byte, 0, vec.Length()*poly.PackedSize(d))
+	for i, p := range vec {
+		b, err := p.PackBits(d)
+		if err != nil {
+			return nil, fmt.Errorf("PackBits: entry %d: %w", i, err)
+		}
+		ret = append(ret, b...)
+	}
+	return ret, nil
+}
+
+// The vector length is taken from the length of data
+func (vec *PolyQVector) UnpackBits(data []byte, d int) error {
+	if err := checkWidth("UnpackBits", d); err != nil {
+		return err
+	}
+	return vec.unpack(data, poly.PackedSize(d), func(p *poly.PolyQ, chunk []byte) error {
+		return p.UnpackBits(chunk, d)
+	})
+}
+
+// Concatenation of PolyQ.PackBounded of every entry
+func (vec PolyQVector) PackBounded(lo, hi int64) ([]byte, error) {
+	if err := checkRange("PackBounded", lo, hi); err != nil {
+		return nil, err
+	}
+	ret := make([]byte, 0)
+	for i, p := range vec {
+		b, err := p.PackBounded(lo, hi)
+		if err != nil {
+			return nil, fmt.Errorf("PackBounded: entry %d: %w", i, err)
+		}
+		ret = append(ret, b...)
+	}
+	return ret, nil
+}
+
+// The vector length is taken from the length of data
+func (vec *PolyQVector) UnpackBounded(data []byte, lo, hi int64) error {
+	if err := checkRange("UnpackBounded", lo, hi); err != nil {
+		return err
+	}
+	return vec.unpack(data, poly.PackedSize(bits.Len64(uint64(hi-lo))), func(p *poly.PolyQ, chunk []byte) error {
+		return p.UnpackBounded(chunk, lo, hi)
+	})
+}
+
+func (vec *PolyQVector) unpack(data []byte, size int, unpackPoly func(*poly.PolyQ, []byte) error) error {
+	if size == 0 || len(data)%size != 0 {
+		return fmt.Errorf("unpack: %d bytes is not a multiple of %d", len(data), size)
+	}
+
+	ret := make(PolyQVector, len(data)/size)
+	for i := range ret {
+		if err := unpackPoly(&ret[i], data[i*size:(i+1)*size]); err != nil {
+			return fmt.Errorf("unpack: entry %d: %w", i, err)
+		}
+	}
+	*vec = ret
+	return nil
+}
@@ -0,0 +1,54 @@
+package vector
+
+import (
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/internal/parse"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// Accepts the String form, "PolyQVector{p0, p1, ...}", where every entry
+// uses the poly.ParsePolyQ syntax, and the CoeffString form
+func ParsePolyQVector(s string) (PolyQVector, error) {
+	entries, err := parseEntries(s, "PolyQVector")
+	if err != nil {
+		return nil, fmt.Errorf("ParsePolyQVector: %w", err)
+	}
+
+	ret := make(PolyQVector, len(entries))
+	for i, entry := range entries {
+		if ret[i], err = poly.ParsePolyQ(entry); err != nil {
+			return nil, fmt.Errorf("ParsePolyQVector: entry %d: %w", i, err)
+		}
+	}
+	return ret, nil
+}
+
+// Accepts the String form, "PolyVector{p0, p1, ...}", where every entry
+// uses the poly.ParsePoly syntax, and the CoeffString form
+func ParsePolyVector(s string) (PolyVector, error) {
+	entries, err := parseEntries(s, "PolyVector")
+	if err != nil {
+		return nil, fmt.Errorf("ParsePolyVector: %w", err)
+	}
+
+	ret := make(PolyVector, len(entries))
+	for i, entry := range entries {
+		if ret[i], err = poly.ParsePoly(entry); err != nil {
+			return nil, fmt.Errorf("ParsePolyVector: entry %d: %w", i, err)
+		}
+	}
+	return ret, nil
+}
+
+func parseEntries(s, name string) ([]string, error) {
+	inner, err := parse.Unwrap(s, name, "Vector")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+	}
+	entries, err := parse.SplitTopLevel(inner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", poly.ErrSyntax, err)
+	}
+	return entries, nil
+}
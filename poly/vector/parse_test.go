@@ -0,0 +1,45 @@
+package vector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+func TestParsePolyQVectorRoundTrip(t *testing.T) {
+	vec := NewRandomPolyQVector(nil, 3)
+	for _, s := range []string{vec.String(), vec.CoeffString(), Vector[poly.PolyQ](vec).String()} {
+		parsed, err := ParsePolyQVector(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(vec) {
+			t.Errorf("round trip of %.40q... failed", s)
+		}
+	}
+
+	empty, err := ParsePolyQVector(PolyQVector{}.String())
+	if err != nil || empty.Length() != 0 {
+		t.Errorf("empty vector not parsed: %v", err)
+	}
+}
+
+func TestParsePolyVector(t *testing.T) {
+	vec := NewPolyVectorFromCoeffs([][]int64{{1, -2}, {0}, {0, 0, 3}})
+	for _, s := range []string{vec.String(), vec.CoeffString(), "[1 - 2x, 0, 3*x^2]"} {
+		parsed, err := ParsePolyVector(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsed.Equals(vec) {
+			t.Errorf("%q parsed as %v", s, parsed)
+		}
+	}
+
+	for _, s := range []string{"PolyQVector{1}", "PolyVector{1, }", "[1, [2]", "PolyVector{x^}"} {
+		if _, err := ParsePolyVector(s); !errors.Is(err, poly.ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", s, err)
+		}
+	}
+}
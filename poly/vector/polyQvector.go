@@ -3,13 +3,16 @@ package vector
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
+	"io"
 	"log"
 	"math"
+	mrand "math/rand/v2"
 	"strings"
 
 	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/internal/compress"
 	"github.com/isri-pqc/latticehelper/poly"
-	"github.com/raszia/gotiny"
 	"github.com/tuneinsight/lattigo/v5/ring"
 )
 
@@ -21,7 +24,7 @@ func (vec PolyQVector) Serialize() []byte {
 	if err != nil {
 		panic(err)
 	}
-	_, err = buf.Write(gotiny.MarshalCompress(&vec))
+	_, err = buf.Write(compress.Marshal(vec.ringPolys()))
 	if err != nil {
 		panic(err)
 	}
@@ -33,14 +36,28 @@ func DeserializePolyQVector(data []byte) PolyQVector {
 	_ = binary.Read(bytes.NewReader(data[:2]), binary.LittleEndian, &len)
 
 	p := NewZeroPolyQVector(int(len))
-	n := gotiny.UnmarshalCompress(data[2:], &p)
+	polys := p.ringPolys()
+	n := compress.Unmarshal(data[2:], polys)
 	if n == 0 {
 		panic("failed to deserialize PolyQVector")
 	}
 
+	for i := range p {
+		p[i] = poly.PolyQ{Poly: (*polys)[i]}
+	}
 	return p
 }
 
+// Serialize encodes the lattigo polynomials directly, so its format does
+// not depend on PolyQ.MarshalBinary
+func (vec PolyQVector) ringPolys() *[]ring.Poly {
+	polys := make([]ring.Poly, vec.Length())
+	for i, p := range vec {
+		polys[i] = p.Poly
+	}
+	return &polys
+}
+
 func NewPolyQVectorFromCoeffs(coeffs [][]int64) PolyQVector {
 	vec := make(PolyQVector, len(coeffs))
 	for i, coeffsI := range coeffs {
@@ -49,6 +66,20 @@ func NewPolyQVectorFromCoeffs(coeffs [][]int64) PolyQVector {
 	return vec
 }
 
+// Inverse of PolyQVector.Listize, the flat view used next to PolyQMatrix.IntMatrix
+func NewPolyQVectorFromList(list []int64) (PolyQVector, error) {
+	n := latticehelper.MainRing.N()
+	if len(list)%n != 0 {
+		return nil, fmt.Errorf("NewPolyQVectorFromList: length %d is not a multiple of %d", len(list), n)
+	}
+
+	vec := make(PolyQVector, len(list)/n)
+	for i := range vec {
+		vec[i] = poly.NewPolyQFromCoeffs(list[i*n : (i+1)*n]...)
+	}
+	return vec, nil
+}
+
 func NewZeroPolyQVector(length int) PolyQVector {
 	vec := make(PolyQVector, length)
 	for i := 0; i < len(vec); i++ {
@@ -80,6 +111,27 @@ func NewRandomPolyQVectorWithMaxInfNormWithSeed(seed []byte, length int, maxInfN
 	return vec
 }
 
+// Coefficients uniform in [-bound, bound], unlike NewRandomPolyQWithMaxInfNorm
+// which draws 0 twice as often. Every polynomial is expanded with ChaCha8 from
+// its own 32-byte seed read from rand, crypto/rand if nil.
+func NewUniformPolyQVectorFromReader(rand io.Reader, length int, bound int64) (PolyQVector, error) {
+	rand = latticehelper.RandReader(rand)
+	vec := make(PolyQVector, length)
+	var seed [32]byte
+	coeffs := make([]int64, latticehelper.MainRing.N())
+	for i := range vec {
+		if _, err := io.ReadFull(rand, seed[:]); err != nil {
+			return nil, err
+		}
+		r := mrand.New(mrand.NewChaCha8(seed))
+		for j := range coeffs {
+			coeffs[j] = r.Int64N(2*bound+1) - bound
+		}
+		vec[i] = poly.NewPolyQFromCoeffs(coeffs...)
+	}
+	return vec, nil
+}
+
 func (vec PolyQVector) Power2Round(d int64) (PolyQVector, PolyQVector) {
 	r1polys := make(PolyQVector, vec.Length())
 	r0polys := make(PolyQVector, vec.Length())
@@ -108,6 +160,23 @@ func (vec PolyQVector) HighBits(alpha int64) PolyQVector {
 	return newVec
 }
 
+func (vec PolyQVector) LowBits(alpha int64) PolyQVector {
+	newVec := make(PolyQVector, len(vec))
+	for i := 0; i < len(newVec); i++ {
+		newVec[i] = vec[i].LowBits(alpha)
+	}
+	return newVec
+}
+
+func (vec PolyQVector) CheckNormBound(bound int64) bool {
+	for _, poly := range vec {
+		if poly.CheckNormBound(bound) {
+			return true
+		}
+	}
+	return false
+}
+
 func (vec PolyQVector) CoeffString() string {
 	var sb strings.Builder
 	sb.WriteString("[")
@@ -173,6 +242,16 @@ func (vec PolyQVector) SecondNorm() float64 {
 	return math.Sqrt(float64(sum))
 }
 
+// Euclidean norm of the canonical embedding of the whole vector
+func (vec PolyQVector) CanonicalNorm() float64 {
+	sum := 0.0
+	for _, currentPoly := range vec {
+		norm := currentPoly.CanonicalNorm()
+		sum += norm * norm
+	}
+	return math.Sqrt(sum)
+}
+
 func (vec PolyQVector) ScaledByPolyQ(inputPoly poly.PolyQ) PolyQVector {
 	newVec := make(PolyQVector, vec.Length())
 	for i, currentPoly := range vec {
@@ -230,22 +309,49 @@ func (vec PolyQVector) DotProduct(inputPolyQVector PolyQVector) poly.PolyQ {
 		log.Panic("DotProduct: two vectors don't have the same length.")
 	}
 
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
 	newPoly := poly.NewPolyQ()
+	vecNTT := r.NewPoly()
+	inputNTT := r.NewPoly()
 
 	for i := 0; i < vec.Length(); i++ {
-		latticehelper.MainRing.NTT(vec[i].Poly, vec[i].Poly)
-		latticehelper.MainRing.NTT(inputPolyQVector[i].Poly, inputPolyQVector[i].Poly)
+		r.NTT(vec[i].Poly, vecNTT)
+		r.NTT(inputPolyQVector[i].Poly, inputNTT)
 
-		latticehelper.MainRing.MulCoeffsBarrettThenAdd(vec[i].Poly, inputPolyQVector[i].Poly, newPoly.Poly)
-
-		latticehelper.MainRing.INTT(vec[i].Poly, vec[i].Poly)
-		latticehelper.MainRing.INTT(inputPolyQVector[i].Poly, inputPolyQVector[i].Poly)
+		r.MulCoeffsBarrettThenAdd(vecNTT, inputNTT, newPoly.Poly)
 	}
-	latticehelper.MainRing.INTT(newPoly.Poly, newPoly.Poly)
+	r.INTT(newPoly.Poly, newPoly.Poly)
 
 	return newPoly
 }
 
+// Rows vec[i] * other, convertible with matrix.PolyQMatrix(...)
+func (vec PolyQVector) OuterProduct(inputPolyQVector PolyQVector) []PolyQVector {
+	r := latticehelper.MainRing.AtLevel(latticehelper.MainRing.Level())
+
+	inputNTT := make([]ring.Poly, inputPolyQVector.Length())
+	for j, p := range inputPolyQVector {
+		inputNTT[j] = r.NewPoly()
+		r.NTT(p.Poly, inputNTT[j])
+	}
+
+	vecNTT := r.NewPoly()
+	ret := make([]PolyQVector, vec.Length())
+	for i, p := range vec {
+		r.NTT(p.Poly, vecNTT)
+
+		ret[i] = make(PolyQVector, inputPolyQVector.Length())
+		for j := range inputNTT {
+			currentPoly := poly.NewPolyQ()
+			r.MulCoeffsBarrett(vecNTT, inputNTT[j], currentPoly.Poly)
+			r.INTT(currentPoly.Poly, currentPoly.Poly)
+			ret[i][j] = currentPoly
+		}
+	}
+	return ret
+}
+
 func (vec PolyQVector) Equals(other PolyQVector) bool {
 	for i := 0; i < vec.Length(); i++ {
 		if !vec[i].Equals(other[i]) {
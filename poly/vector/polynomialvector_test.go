@@ -1,6 +1,8 @@
 package vector
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/isri-pqc/latticehelper"
@@ -70,3 +72,193 @@ func TestPolyQVectorDotProduct(t *testing.T) {
 		t.Errorf("Expected %v but got %v", expected, result)
 	}
 }
+
+func TestPolyQVectorOuterProduct(t *testing.T) {
+	v := NewRandomPolyQVector(nil, 3)
+	w := NewRandomPolyQVector(nil, 2)
+
+	outer := v.OuterProduct(w)
+	if len(outer) != 3 || outer[0].Length() != 2 {
+		t.Fatalf("OuterProduct has shape %dx%d", len(outer), outer[0].Length())
+	}
+	for i := range v {
+		for j := range w {
+			if !outer[i][j].Equals(v[i].Mul(w[j])) {
+				t.Errorf("OuterProduct entry (%d, %d) is wrong", i, j)
+			}
+		}
+	}
+}
+
+func TestPolyVectorOuterProduct(t *testing.T) {
+	v := PolyVector{poly.NewPolyFromCoeffs(1, 2), poly.NewPolyFromCoeffs(-1)}
+	w := PolyVector{poly.NewPolyFromCoeffs(3), poly.NewPolyFromCoeffs(0, 1)}
+
+	outer := v.OuterProduct(w)
+	expected := [][]poly.Poly{
+		{poly.NewPolyFromCoeffs(3, 6), poly.NewPolyFromCoeffs(0, 1, 2)},
+		{poly.NewPolyFromCoeffs(-3), poly.NewPolyFromCoeffs(0, -1)},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if !outer[i][j].Equals(expected[i][j]) {
+				t.Errorf("Expected %v but got %v", expected[i][j], outer[i][j])
+			}
+		}
+	}
+}
+
+func TestPolyQVectorHadamard(t *testing.T) {
+	v := NewRandomPolyQVector(nil, 3)
+	w := NewRandomPolyQVector(nil, 3)
+
+	h := v.Hadamard(w)
+	for i := range v {
+		if !h[i].Equals(v[i].Mul(w[i])) {
+			t.Errorf("Hadamard entry %d is wrong", i)
+		}
+	}
+
+	// Sum of the Hadamard product is the dot product
+	if !h.Sum().Equals(v.DotProduct(w)) {
+		t.Error("Sum of Hadamard product differs from DotProduct")
+	}
+
+	// In NTT slots, the Hadamard product is slot-wise
+	vSlots, wSlots := v.NTTSlots(), w.NTTSlots()
+	slotwise := make([][]int64, v.Length())
+	for i := range slotwise {
+		slotwise[i] = poly.NewPolyQFromCoeffs(vSlots[i]...).CoeffMul(poly.NewPolyQFromCoeffs(wSlots[i]...)).Listize()
+	}
+	if !NewPolyQVectorFromNTTSlots(NewPolyQVectorFromNTTSlots(vSlots).NTTSlots()).Equals(v) {
+		t.Error("NTT slots round trip failed")
+	}
+	if !NewPolyQVectorFromNTTSlots(slotwise).Equals(h) {
+		t.Error("slot-wise product differs from Hadamard")
+	}
+}
+
+func TestPolyQVectorBinaryConstraint(t *testing.T) {
+	b := PolyQVector{poly.NewPolyQFromCoeffs(1, 0, 1), poly.NewPolyQFromCoeffs(0, 1)}
+	ones := PolyQVector{poly.NewPolyQFromCoeffs(1, 1, 1), poly.NewPolyQFromCoeffs(1, 1)}
+
+	if !b.CoeffMul(b.Sub(ones)).Sum().Equals(poly.NewPolyQ()) {
+		t.Error("binary vector fails b ∘ (b - 1) = 0")
+	}
+
+	b[1] = poly.NewPolyQFromCoeffs(0, 3)
+	if b.CoeffMul(b.Sub(ones)).Sum().Equals(poly.NewPolyQ()) {
+		t.Error("non-binary vector passes b ∘ (b - 1) = 0")
+	}
+}
+
+func TestPolyVectorElementwise(t *testing.T) {
+	v := PolyVector{poly.NewPolyFromCoeffs(1, 2), poly.NewPolyFromCoeffs(3)}
+	w := PolyVector{poly.NewPolyFromCoeffs(0, 1), poly.NewPolyFromCoeffs(-2, 4)}
+
+	if !v.Hadamard(w).Equals(PolyVector{poly.NewPolyFromCoeffs(0, 1, 2), poly.NewPolyFromCoeffs(-6, 12)}) {
+		t.Error("Hadamard failed")
+	}
+	if !v.CoeffMul(w).Equals(PolyVector{poly.NewPolyFromCoeffs(0, 2), poly.NewPolyFromCoeffs(-6)}) {
+		t.Error("CoeffMul failed")
+	}
+	if !v.Sum().Equals(poly.NewPolyFromCoeffs(4, 2)) {
+		t.Error("Sum failed")
+	}
+}
+
+func TestPolyQVectorPack(t *testing.T) {
+	v := NewRandomPolyQVectorWithMaxInfNorm(3, 4)
+
+	b, err := v.PackBounded(-4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 3*poly.PackedSize(4) {
+		t.Fatalf("packed into %d bytes", len(b))
+	}
+
+	var decoded PolyQVector
+	if err := decoded.UnpackBounded(b, -4, 4); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equals(v) || decoded.Length() != 3 {
+		t.Error("bounded round trip failed")
+	}
+
+	w := PolyQVector{poly.NewPolyQFromCoeffs(1, 1023), poly.NewPolyQFromCoeffs(512)}
+	b, err = w.PackBits(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := decoded.UnpackBits(b, 10); err != nil || !decoded.Equals(w) {
+		t.Error("bit round trip failed")
+	}
+
+	if err := decoded.UnpackBits(b[1:], 10); err == nil {
+		t.Error("data of the wrong length accepted")
+	}
+	if _, err := w.PackBits(9); err == nil {
+		t.Error("1023 packed into 9 bits")
+	}
+
+	// Same errors as the polynomial level
+	if err := decoded.UnpackBits(b, 0); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("width 0: %v", err)
+	}
+	if err := decoded.UnpackBounded(b, 4, -4); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("empty range: %v", err)
+	}
+	if _, err := NewZeroPolyQVector(2).PackBits(-1); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("packing with width -1: %v", err)
+	}
+
+	// A single value would need 0 bits, refused both ways and at both levels
+	if _, err := NewZeroPolyQVector(2).PackBounded(0, 0); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("packing [0, 0]: %v", err)
+	}
+	if err := decoded.UnpackBounded(nil, 0, 0); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("unpacking [0, 0]: %v", err)
+	}
+	if _, err := poly.NewPolyQ().PackBounded(0, 0); !errors.Is(err, poly.ErrPackRange) {
+		t.Errorf("packing a polynomial into [0, 0]: %v", err)
+	}
+}
+
+func TestUniformPolyQVectorFromReader(t *testing.T) {
+	seeds := bytes.Repeat([]byte{7}, 3*32)
+	v, err := NewUniformPolyQVectorFromReader(bytes.NewReader(seeds), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, _ := NewUniformPolyQVectorFromReader(bytes.NewReader(seeds), 3, 2)
+	if v.Length() != 3 || !v.Equals(w) {
+		t.Error("same reader gave different vectors")
+	}
+
+	if _, err := NewUniformPolyQVectorFromReader(bytes.NewReader(seeds[:90]), 3, 2); err == nil {
+		t.Error("short reader accepted")
+	}
+
+	// Every value of [-2, 2] about equally often, 0 included
+	v, err = NewUniformPolyQVectorFromReader(nil, 200, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[int64]int)
+	q := latticehelper.MainRing.Modulus().Int64()
+	for _, p := range v {
+		for _, c := range p.Listize() {
+			counts[poly.CenteredModulo(c, q)]++
+		}
+	}
+	expected := 200 * latticehelper.MainRing.N() / 5
+	for c := int64(-2); c <= 2; c++ {
+		if counts[c] < expected*9/10 || counts[c] > expected*11/10 {
+			t.Errorf("%d drawn %d times, expected about %d", c, counts[c], expected)
+		}
+	}
+	if len(counts) != 5 {
+		t.Errorf("values outside [-2, 2]: %v", counts)
+	}
+}
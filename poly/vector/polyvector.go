@@ -2,28 +2,44 @@ package vector
 
 import (
 	"log"
+	"math"
 	"strings"
 
 	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/internal/compress"
 	"github.com/isri-pqc/latticehelper/poly"
-	"github.com/raszia/gotiny"
 )
 
 type PolyVector []poly.Poly
 
 func (vec PolyVector) Serialize() []byte {
-	return gotiny.MarshalCompress(&vec)
+	return compress.Marshal(vec.rawCoeffs())
 }
 
 func DeserializePolyVector(data []byte) PolyVector {
-	var vec PolyVector
-	n := gotiny.UnmarshalCompress(data, &vec)
+	var raw [][]int64
+	n := compress.Unmarshal(data, &raw)
 	if n == 0 {
 		panic("failed to deserialize")
 	}
+
+	vec := make(PolyVector, len(raw))
+	for i, coeffs := range raw {
+		vec[i] = poly.Poly(coeffs)
+	}
 	return vec
 }
 
+// Serialize encodes plain [][]int64, so its format does not depend on
+// Poly.MarshalBinary
+func (vec PolyVector) rawCoeffs() *[][]int64 {
+	raw := make([][]int64, vec.Length())
+	for i, p := range vec {
+		raw[i] = p
+	}
+	return &raw
+}
+
 func NewPolyVectorFromCoeffs(coeffs [][]int64) PolyVector {
 	vec := make(PolyVector, len(coeffs))
 	for i, coeffsI := range coeffs {
@@ -117,6 +133,16 @@ func (vec PolyVector) CheckNormBound(bound int64) bool {
 	return false
 }
 
+// Euclidean norm of the canonical embedding of the whole vector
+func (vec PolyVector) CanonicalNorm() float64 {
+	sum := 0.0
+	for _, currentPoly := range vec {
+		norm := currentPoly.CanonicalNorm()
+		sum += norm * norm
+	}
+	return math.Sqrt(sum)
+}
+
 func (vec PolyVector) LowBits(alpha int64) PolyVector {
 	newVec := make(PolyVector, len(vec))
 	for i := 0; i < len(newVec); i++ {
@@ -125,6 +151,25 @@ func (vec PolyVector) LowBits(alpha int64) PolyVector {
 	return newVec
 }
 
+func (vec PolyVector) HighBits(alpha int64) PolyVector {
+	newVec := make(PolyVector, len(vec))
+	for i := 0; i < len(newVec); i++ {
+		newVec[i] = vec[i].HighBits(alpha)
+	}
+	return newVec
+}
+
+func (vec PolyVector) InfiniteNorm() int64 {
+	max := int64(0)
+	for _, currentPoly := range vec {
+		maxPoly := currentPoly.InfiniteNorm()
+		if maxPoly > max {
+			max = maxPoly
+		}
+	}
+	return max
+}
+
 func (vec PolyVector) ScaledByPoly(inputPoly poly.Poly) PolyVector {
 	ret := make(PolyVector, len(vec))
 	for i, currentPoly := range vec {
@@ -186,6 +231,18 @@ func (vec PolyVector) DotProduct(inputPolyVector PolyVector) poly.Poly {
 	return ret
 }
 
+// Rows vec[i] * other, convertible with matrix.PolyMatrix(...)
+func (vec PolyVector) OuterProduct(inputPolyVector PolyVector) []PolyVector {
+	ret := make([]PolyVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = make(PolyVector, inputPolyVector.Length())
+		for j, other := range inputPolyVector {
+			ret[i][j] = p.Mul(other)
+		}
+	}
+	return ret
+}
+
 func (vec PolyVector) Equals(other PolyVector) bool {
 	for i := 0; i < vec.Length(); i++ {
 		if !vec[i].Equals(other[i]) {
@@ -0,0 +1,121 @@
+package twoparty
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+type MessageType byte
+
+const (
+	// Key generation: commitment to t_i, then t_i with the commitment nonce
+	MsgKeyCommit MessageType = iota + 1
+	MsgKeyReveal
+	// Signing: commitment to w_i, w_i with the nonce, then z_i or an abort
+	MsgCommit
+	MsgReveal
+	MsgResponse
+	MsgAbort
+)
+
+func (t MessageType) String() string {
+	switch t {
+	case MsgKeyCommit:
+		return "KeyCommit"
+	case MsgKeyReveal:
+		return "KeyReveal"
+	case MsgCommit:
+		return "Commit"
+	case MsgReveal:
+		return "Reveal"
+	case MsgResponse:
+		return "Response"
+	case MsgAbort:
+		return "Abort"
+	}
+	return fmt.Sprintf("MessageType(%d)", byte(t))
+}
+
+// Commitment is set for the commit messages, Nonce and Vector for the
+// reveals and Vector for a response. Vectors use the wire encoding of
+// PolyQVector.MarshalBinary.
+type Message struct {
+	Type MessageType
+	// Index of the sender, 0 or 1
+	From int
+	// Signing attempt the message belongs to, 0 during key generation
+	Attempt    uint32
+	Commitment []byte
+	Nonce      []byte
+	Vector     vector.PolyQVector
+}
+
+func (msg Message) MarshalBinary() ([]byte, error) {
+	if msg.From != 0 && msg.From != 1 {
+		return nil, fmt.Errorf("%w: sender %d", ErrMessage, msg.From)
+	}
+	b := []byte{byte(msg.Type), byte(msg.From)}
+	b = binary.LittleEndian.AppendUint32(b, msg.Attempt)
+
+	switch msg.Type {
+	case MsgKeyCommit, MsgCommit:
+		if len(msg.Commitment) != 32 {
+			return nil, fmt.Errorf("%w: %v with a %d-byte commitment", ErrMessage, msg.Type, len(msg.Commitment))
+		}
+		b = append(b, msg.Commitment...)
+	case MsgKeyReveal, MsgReveal:
+		if len(msg.Nonce) != 32 {
+			return nil, fmt.Errorf("%w: %v with a %d-byte nonce", ErrMessage, msg.Type, len(msg.Nonce))
+		}
+		b = append(b, msg.Nonce...)
+		fallthrough
+	case MsgResponse:
+		vec, err := msg.Vector.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, vec...)
+	case MsgAbort:
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrMessage, msg.Type)
+	}
+	return b, nil
+}
+
+func (msg *Message) UnmarshalBinary(data []byte) error {
+	if len(data) < 6 || data[1] > 1 {
+		return fmt.Errorf("%w: bad header", ErrMessage)
+	}
+	ret := Message{Type: MessageType(data[0]), From: int(data[1]), Attempt: binary.LittleEndian.Uint32(data[2:])}
+	rest := data[6:]
+
+	switch ret.Type {
+	case MsgKeyCommit, MsgCommit:
+		if len(rest) != 32 {
+			return fmt.Errorf("%w: %v with %d bytes of payload", ErrMessage, ret.Type, len(rest))
+		}
+		ret.Commitment = append([]byte(nil), rest...)
+	case MsgKeyReveal, MsgReveal:
+		if len(rest) < 32 {
+			return fmt.Errorf("%w: %v with %d bytes of payload", ErrMessage, ret.Type, len(rest))
+		}
+		ret.Nonce = append([]byte(nil), rest[:32]...)
+		rest = rest[32:]
+		fallthrough
+	case MsgResponse:
+		if err := ret.Vector.UnmarshalBinary(rest); err != nil {
+			return err
+		}
+	case MsgAbort:
+		if len(rest) != 0 {
+			return fmt.Errorf("%w: abort with a payload", ErrMessage)
+		}
+	default:
+		return fmt.Errorf("%w: %v", ErrMessage, ret.Type)
+	}
+
+	*msg = ret
+	return nil
+}
@@ -0,0 +1,80 @@
+// Package twoparty implements a two-party Dilithium-style signature: both
+// parties hold an additive share of the secret key and sign together.
+//
+// The protocol follows the three-round scheme of Damgard, Orlandi,
+// Takahashi and Tibouchi (PKC 2021) with hash commitments. The public
+// matrix is [A | I] with A expanded from a seed, so t = A*s1 + s2 and a
+// signature (c, z) verifies with A*z1 + z2 - c*t = w. Full w is hashed, there
+// is no rounding, so keys and signatures are larger than ML-DSA ones.
+//
+// Key generation: commit to t_i, reveal t_i and the commitment randomness,
+// t = t_0 + t_1. Signing: commit to w_i = [A | I] y_i, reveal w_i, derive the
+// challenge c = H(t, w, message), answer with z_i = y_i + c*s_i or abort when
+// z_i is too large. Any abort restarts signing with fresh masks.
+//
+// Works in any ring set up with latticehelper.InitSingle.
+package twoparty
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+const SeedSize = 32
+
+var (
+	ErrParameters = errors.New("twoparty: invalid parameters")
+	ErrState      = errors.New("twoparty: unexpected message for the current state")
+	ErrMessage    = errors.New("twoparty: malformed message")
+	ErrOpening    = errors.New("twoparty: commitment does not open")
+	ErrResponse   = errors.New("twoparty: peer response does not verify")
+	ErrAttempts   = errors.New("twoparty: too many restarts")
+)
+
+type Parameters struct {
+	// A is K x L, secret shares and masks have L + K entries
+	K, L int
+	// Secret coefficients in [-Eta, Eta]
+	Eta int64
+	// Number of +-1 coefficients of a challenge, at most 64
+	Tau int
+	// Mask coefficients in [-Gamma, Gamma]
+	Gamma int64
+	// Signing attempts before giving up, 0 for no limit
+	MaxAttempts int
+}
+
+// Sized like ML-DSA-44 for latticehelper.InitSingle(256, 8380417). Gamma is
+// larger than ML-DSA's gamma1 so that both parties pass rejection together
+// about half of the time.
+var DefaultParameters = &Parameters{K: 4, L: 4, Eta: 2, Tau: 39, Gamma: 1 << 19, MaxAttempts: 100}
+
+// Largest coefficient of c*s_i
+func (params *Parameters) Beta() int64 {
+	return int64(params.Tau) * params.Eta
+}
+
+// z_i is kept only below this bound
+func (params *Parameters) ResponseBound() int64 {
+	return params.Gamma - params.Beta()
+}
+
+func (params *Parameters) check() error {
+	if latticehelper.MainRing == nil {
+		return fmt.Errorf("%w: latticehelper.MainRing is not initialized", ErrParameters)
+	}
+	if params.K < 1 || params.L < 1 || params.Eta < 1 {
+		return fmt.Errorf("%w: K = %d, L = %d, Eta = %d", ErrParameters, params.K, params.L, params.Eta)
+	}
+	if params.Tau < 1 || params.Tau > 64 || params.Tau > latticehelper.MainRing.N() {
+		return fmt.Errorf("%w: Tau = %d", ErrParameters, params.Tau)
+	}
+	// z = z_0 + z_1 must not wrap around q
+	q := latticehelper.MainRing.Modulus().Int64()
+	if params.ResponseBound() < 1 || 4*params.Gamma >= q {
+		return fmt.Errorf("%w: Gamma = %d for beta = %d and q = %d", ErrParameters, params.Gamma, params.Beta(), q)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package twoparty
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+type state int
+
+const (
+	stateNew state = iota
+	stateKeyCommitted
+	stateKeyRevealed
+	stateReady
+	stateCommitted
+	stateRevealed
+	stateResponded
+	stateFailed
+)
+
+// One side of the protocol. Every Start and Handle call returns the message
+// to send to the peer, if any. A Party is not safe for concurrent use and
+// stops for good after the first error.
+type Party struct {
+	params *Parameters
+	id     int
+	rand   io.Reader
+	seed   []byte
+	a      *matrix.PrecomputedPolyQMatrix
+	state  state
+
+	// Own share and commitment nonce, and the peer's commitment
+	s, own     vector.PolyQVector
+	nonce      []byte
+	ownCommit  []byte
+	peerCommit []byte
+
+	// Key shares, t = t_0 + t_1
+	tOwn, tPeer, t vector.PolyQVector
+
+	message  []byte
+	attempt  uint32
+	y, wPeer vector.PolyQVector
+	cTilde   []byte
+	c        poly.PolyQ
+	z        vector.PolyQVector
+	aborted  bool
+
+	signature *Signature
+}
+
+// Party id (0 or 1) with the public matrix seed both parties agreed on.
+// Randomness comes from rand, crypto/rand if nil.
+func NewParty(params *Parameters, id int, seed []byte, rand io.Reader) (*Party, error) {
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+	if id != 0 && id != 1 {
+		return nil, fmt.Errorf("%w: party %d", ErrParameters, id)
+	}
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: %d-byte seed", ErrParameters, len(seed))
+	}
+	return &Party{
+		params: params,
+		id:     id,
+		rand:   latticehelper.RandReader(rand),
+		seed:   append([]byte(nil), seed...),
+		a:      matrix.Precompute(params.PublicMatrix(seed)),
+	}, nil
+}
+
+func (p *Party) ID() int {
+	return p.id
+}
+
+// True after key generation and after every finished signature
+func (p *Party) Ready() bool {
+	return p.state == stateReady
+}
+
+// Nil before key generation is done
+func (p *Party) PublicKey() *PublicKey {
+	if p.t == nil {
+		return nil
+	}
+	return &PublicKey{Params: p.params, Seed: append([]byte(nil), p.seed...), T: p.t}
+}
+
+// Signature of the last finished signing session, nil if there is none
+func (p *Party) Signature() *Signature {
+	return p.signature
+}
+
+func (p *Party) random(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := io.ReadFull(p.rand, b)
+	return b, err
+}
+
+// L + K polynomials with coefficients uniform in [-bound, bound]. The
+// rejection step of the signature relies on the masks being uniform.
+func (p *Party) short(bound int64) (vector.PolyQVector, error) {
+	return vector.NewUniformPolyQVectorFromReader(p.rand, p.params.L+p.params.K, bound)
+}
+
+func (p *Party) fail(err error) (*Message, error) {
+	p.state = stateFailed
+	return nil, err
+}
+
+// Samples the secret share and commits to t_i
+func (p *Party) StartKeyGen() (*Message, error) {
+	if p.state != stateNew {
+		return p.fail(fmt.Errorf("%w: key generation already started", ErrState))
+	}
+
+	s, err := p.short(p.params.Eta)
+	if err != nil {
+		return p.fail(err)
+	}
+	if p.nonce, err = p.random(32); err != nil {
+		return p.fail(err)
+	}
+	p.s, p.tOwn = s, p.a.MulVecNew(s)
+
+	p.state = stateKeyCommitted
+	p.ownCommit = p.commit("twoparty key", p.id, p.tOwn, p.nonce)
+	return &Message{Type: MsgKeyCommit, From: p.id, Commitment: p.ownCommit}, nil
+}
+
+// Commits to w_i = [A | I]*y_i for the current attempt
+func (p *Party) StartSign(message []byte) (*Message, error) {
+	if p.state != stateReady {
+		return p.fail(fmt.Errorf("%w: signing needs a finished key generation", ErrState))
+	}
+	p.message = append([]byte(nil), message...)
+	p.attempt = 0
+	p.signature = nil
+	return p.commitMask()
+}
+
+func (p *Party) commitMask() (*Message, error) {
+	if p.params.MaxAttempts > 0 && int(p.attempt) >= p.params.MaxAttempts {
+		return p.fail(ErrAttempts)
+	}
+
+	y, err := p.short(p.params.Gamma)
+	if err != nil {
+		return p.fail(err)
+	}
+	if p.nonce, err = p.random(32); err != nil {
+		return p.fail(err)
+	}
+	p.y, p.own = y, p.a.MulVecNew(y)
+
+	p.state = stateCommitted
+	p.ownCommit = p.commit("twoparty mask", p.id, p.own, p.nonce)
+	return &Message{Type: MsgCommit, From: p.id, Attempt: p.attempt, Commitment: p.ownCommit}, nil
+}
+
+// Advances the state machine with a message of the peer
+func (p *Party) Handle(msg *Message) (*Message, error) {
+	if p.state == stateFailed {
+		return nil, fmt.Errorf("%w: party stopped after an error", ErrState)
+	}
+	if msg.From != 1-p.id || msg.Attempt != p.attempt {
+		return p.fail(fmt.Errorf("%w: %v from party %d for attempt %d", ErrState, msg.Type, msg.From, msg.Attempt))
+	}
+
+	switch {
+	case msg.Type == MsgKeyCommit && p.state == stateKeyCommitted:
+		if err := p.storeCommitment(msg); err != nil {
+			return p.fail(err)
+		}
+		p.state = stateKeyRevealed
+		return &Message{Type: MsgKeyReveal, From: p.id, Nonce: p.nonce, Vector: p.tOwn}, nil
+
+	case msg.Type == MsgKeyReveal && p.state == stateKeyRevealed:
+		if err := p.open("twoparty key", msg); err != nil {
+			return p.fail(err)
+		}
+		p.tPeer, p.t = msg.Vector, p.tOwn.Add(msg.Vector)
+		p.state = stateReady
+		return nil, nil
+
+	case msg.Type == MsgCommit && p.state == stateCommitted:
+		if err := p.storeCommitment(msg); err != nil {
+			return p.fail(err)
+		}
+		p.state = stateRevealed
+		return &Message{Type: MsgReveal, From: p.id, Attempt: p.attempt, Nonce: p.nonce, Vector: p.own}, nil
+
+	case msg.Type == MsgReveal && p.state == stateRevealed:
+		if err := p.open("twoparty mask", msg); err != nil {
+			return p.fail(err)
+		}
+		return p.respond(msg.Vector), nil
+
+	case (msg.Type == MsgResponse || msg.Type == MsgAbort) && p.state == stateResponded:
+		if msg.Type == MsgAbort || p.aborted {
+			p.attempt++
+			return p.commitMask()
+		}
+		return nil, p.finish(msg.Vector)
+	}
+	return p.fail(fmt.Errorf("%w: %v", ErrState, msg.Type))
+}
+
+// An echo of the own commitment could never open, it is refused right away
+func (p *Party) storeCommitment(msg *Message) error {
+	if bytes.Equal(msg.Commitment, p.ownCommit) {
+		return fmt.Errorf("%w: peer sent back our own commitment", ErrOpening)
+	}
+	p.peerCommit = msg.Commitment
+	return nil
+}
+
+// Session data is the matrix seed, followed by the message while signing
+func (p *Party) commit(domain string, from int, vec vector.PolyQVector, nonce []byte) []byte {
+	session := p.seed
+	if p.state >= stateReady {
+		session = h(32, p.seed, p.message)
+	}
+	return commit(domain, session, from, p.attempt, vec, nonce)
+}
+
+func (p *Party) open(domain string, msg *Message) error {
+	if msg.Vector.Length() != p.params.K {
+		return fmt.Errorf("%w: %v with %d polynomials", ErrMessage, msg.Type, msg.Vector.Length())
+	}
+	if subtle.ConstantTimeCompare(p.peerCommit, p.commit(domain, msg.From, msg.Vector, msg.Nonce)) != 1 {
+		return ErrOpening
+	}
+	return nil
+}
+
+// z_i = y_i + c*s_i, or an abort when it would leak s_i
+func (p *Party) respond(wPeer vector.PolyQVector) *Message {
+	p.wPeer = wPeer
+	p.cTilde = challengeHash(p.t, p.own.Add(wPeer), p.message)
+	p.c = p.params.challenge(p.cTilde)
+	p.z = p.y.Add(p.s.ScaledByPolyQ(p.c))
+
+	p.state = stateResponded
+	p.aborted = p.z.InfiniteNorm() >= p.params.ResponseBound()
+	if p.aborted {
+		return &Message{Type: MsgAbort, From: p.id, Attempt: p.attempt}
+	}
+	return &Message{Type: MsgResponse, From: p.id, Attempt: p.attempt, Vector: p.z}
+}
+
+// Checks z_j against the revealed w_j and t_j before combining
+func (p *Party) finish(zPeer vector.PolyQVector) error {
+	if zPeer.Length() != p.params.K+p.params.L || zPeer.InfiniteNorm() >= p.params.ResponseBound() ||
+		!p.a.MulVecNew(zPeer).Sub(p.tPeer.ScaledByPolyQ(p.c)).Equals(p.wPeer) {
+		p.state = stateFailed
+		return ErrResponse
+	}
+
+	p.signature = &Signature{CTilde: p.cTilde, Z: p.z.Add(zPeer)}
+	p.state = stateReady
+	return nil
+}
@@ -0,0 +1,114 @@
+package twoparty
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"golang.org/x/crypto/sha3"
+)
+
+// Seed of A and the joint t = t_0 + t_1
+type PublicKey struct {
+	Params *Parameters
+	Seed   []byte
+	T      vector.PolyQVector
+}
+
+// CTilde is the 32-byte challenge hash, z = z_0 + z_1
+type Signature struct {
+	CTilde []byte
+	Z      vector.PolyQVector
+}
+
+// [A | I] where A is drawn from latticehelper.GetSampler(seed)
+func (params *Parameters) PublicMatrix(seed []byte) matrix.PolyQMatrix {
+	sampler, err := latticehelper.GetSampler(seed)
+	if err != nil {
+		panic(err)
+	}
+	a := matrix.NewRandomPolyQMatrix(sampler, params.K, params.L)
+	return a.Concat(matrix.NewIdentityPolyQMatrix(params.K))
+}
+
+// SHAKE256 of length-prefixed parts, so that different splits never collide
+func h(outLen int, parts ...[]byte) []byte {
+	shake := sha3.NewShake256()
+	for _, part := range parts {
+		shake.Write(binary.LittleEndian.AppendUint64(nil, uint64(len(part))))
+		shake.Write(part)
+	}
+	out := make([]byte, outLen)
+	shake.Read(out)
+	return out
+}
+
+func marshal(vec vector.PolyQVector) []byte {
+	b, err := vec.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Hash commitment to vec with 32 bytes of randomness. The sender, the
+// attempt and the session (matrix seed, and the message when signing) are
+// bound as well, so a commitment echoed back by the peer never opens.
+func commit(domain string, session []byte, from int, attempt uint32, vec vector.PolyQVector, nonce []byte) []byte {
+	header := binary.LittleEndian.AppendUint32([]byte{byte(from)}, attempt)
+	return h(32, []byte(domain), session, header, marshal(vec), nonce)
+}
+
+func challengeHash(t, w vector.PolyQVector, message []byte) []byte {
+	return h(32, []byte("twoparty challenge"), marshal(t), marshal(w), message)
+}
+
+// tau coefficients are +-1, the others zero, as SampleInBall of FIPS 204
+func (params *Parameters) challenge(cTilde []byte) poly.PolyQ {
+	shake := sha3.NewShake256()
+	shake.Write(cTilde)
+
+	var signs [8]byte
+	shake.Read(signs[:])
+	s := binary.LittleEndian.Uint64(signs[:])
+
+	n := latticehelper.MainRing.N()
+	mask := 1<<bits.Len(uint(n-1)) - 1
+	c := make([]int64, n)
+	var b [4]byte
+	for i := n - params.Tau; i < n; i++ {
+		var j int
+		for {
+			shake.Read(b[:])
+			j = int(binary.LittleEndian.Uint32(b[:])) & mask
+			if j <= i {
+				break
+			}
+		}
+		c[i] = c[j]
+		c[j] = 1 - 2*int64(s&1)
+		s >>= 1
+	}
+	return poly.NewPolyQFromCoeffs(c...)
+}
+
+// Same check as single-party Dilithium without hints: z is short and
+// [A | I]*z - c*t hashes to the challenge
+func (pk *PublicKey) Verify(message []byte, sig *Signature) bool {
+	params := pk.Params
+	if params.check() != nil || sig == nil || len(sig.CTilde) != 32 ||
+		sig.Z.Length() != params.K+params.L || pk.T.Length() != params.K {
+		return false
+	}
+	if sig.Z.InfiniteNorm() >= 2*params.ResponseBound() {
+		return false
+	}
+
+	c := params.challenge(sig.CTilde)
+	w := params.PublicMatrix(pk.Seed).VecMul(sig.Z).Sub(pk.T.ScaledByPolyQ(c))
+	return subtle.ConstantTimeCompare(sig.CTilde, challengeHash(pk.T, w, message)) == 1
+}
@@ -0,0 +1,98 @@
+package twoparty
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrClosed = errors.New("twoparty: transport closed")
+
+// Delivers messages to the peer in order
+type Transport interface {
+	Send(msg *Message) error
+	Receive() (*Message, error)
+}
+
+// In-memory transport for tests. Messages go through MarshalBinary and
+// UnmarshalBinary like they would over a network.
+type MemoryTransport struct {
+	in, out chan []byte
+	done    chan struct{}
+}
+
+// Connected ends for party 0 and party 1. Closing either end stops both.
+func NewMemoryTransport() (*MemoryTransport, *MemoryTransport) {
+	a, b := make(chan []byte, 4), make(chan []byte, 4)
+	done := make(chan struct{})
+	return &MemoryTransport{in: a, out: b, done: done}, &MemoryTransport{in: b, out: a, done: done}
+}
+
+func (mt *MemoryTransport) Send(msg *Message) error {
+	b, err := msg.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	select {
+	case mt.out <- b:
+		return nil
+	case <-mt.done:
+		return ErrClosed
+	}
+}
+
+func (mt *MemoryTransport) Receive() (*Message, error) {
+	select {
+	case b := <-mt.in:
+		msg := new(Message)
+		return msg, msg.UnmarshalBinary(b)
+	case <-mt.done:
+		return nil, ErrClosed
+	}
+}
+
+// Safe to call from both ends
+func (mt *MemoryTransport) Close() {
+	select {
+	case <-mt.done:
+	default:
+		close(mt.done)
+	}
+}
+
+// Runs key generation to the end. After an error the peer may still wait
+// for a message, close the transport to stop it.
+func RunKeyGen(p *Party, t Transport) (*PublicKey, error) {
+	if err := run(p, t, p.StartKeyGen); err != nil {
+		return nil, fmt.Errorf("party %d: key generation: %w", p.ID(), err)
+	}
+	return p.PublicKey(), nil
+}
+
+// Signs message together with the peer, restarting after aborts
+func RunSign(p *Party, t Transport, message []byte) (*Signature, error) {
+	if err := run(p, t, func() (*Message, error) { return p.StartSign(message) }); err != nil {
+		return nil, fmt.Errorf("party %d: signing: %w", p.ID(), err)
+	}
+	return p.Signature(), nil
+}
+
+func run(p *Party, t Transport, start func() (*Message, error)) error {
+	out, err := start()
+	for !p.Ready() {
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			if err := t.Send(out); err != nil {
+				return err
+			}
+		}
+
+		var in *Message
+		if in, err = t.Receive(); err != nil {
+			return err
+		}
+		out, err = p.Handle(in)
+	}
+	return err
+}
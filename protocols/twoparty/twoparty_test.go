@@ -0,0 +1,352 @@
+package twoparty
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/kat"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Small enough for the test ring, beta = 16
+var testParams = &Parameters{K: 2, L: 2, Eta: 1, Tau: 16, Gamma: 1 << 16, MaxAttempts: 50}
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func newParties(t *testing.T, params *Parameters) (*Party, *Party) {
+	seed := bytes.Repeat([]byte{1}, SeedSize)
+	p0, err := NewParty(params, 0, seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1, err := NewParty(params, 1, seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p0, p1
+}
+
+// Runs f for both parties over a memory transport
+func both[T any](t *testing.T, p0, p1 *Party, f func(*Party, Transport) (T, error)) (T, T) {
+	t0, t1 := NewMemoryTransport()
+	defer t0.Close()
+
+	var r1 T
+	errs := make(chan error, 1)
+	go func() {
+		var err error
+		r1, err = f(p1, t1)
+		if err != nil {
+			t1.Close()
+		}
+		errs <- err
+	}()
+
+	r0, err := f(p0, t0)
+	if err != nil {
+		t0.Close()
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	return r0, r1
+}
+
+// Delivers m0 to p1 and m1 to p0 at the same time
+func exchange(t *testing.T, p0, p1 *Party, m0, m1 *Message) (*Message, *Message) {
+	out0, err := p0.Handle(m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out1, err := p1.Handle(m0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out0, out1
+}
+
+func TestKeyGenAndSign(t *testing.T) {
+	p0, p1 := newParties(t, testParams)
+	pk0, pk1 := both(t, p0, p1, RunKeyGen)
+	if !pk0.T.Equals(pk1.T) || !bytes.Equal(pk0.Seed, pk1.Seed) {
+		t.Fatal("parties disagree on the public key")
+	}
+	if !pk0.T.Equals(p0.tOwn.Add(p1.tOwn)) {
+		t.Fatal("t is not the sum of the shares")
+	}
+
+	for _, msg := range []string{"first", "second"} {
+		sig0, sig1 := both(t, p0, p1, func(p *Party, tr Transport) (*Signature, error) {
+			return RunSign(p, tr, []byte(msg))
+		})
+		if !bytes.Equal(sig0.CTilde, sig1.CTilde) || !sig0.Z.Equals(sig1.Z) {
+			t.Fatal("parties disagree on the signature")
+		}
+		if !pk0.Verify([]byte(msg), sig0) {
+			t.Fatalf("signature of %q rejected", msg)
+		}
+		if pk0.Verify([]byte("other"), sig0) {
+			t.Fatal("signature accepted for another message")
+		}
+	}
+}
+
+// Gamma just above beta, so nearly every attempt aborts
+func TestRestarts(t *testing.T) {
+	params := *testParams
+	params.Gamma, params.MaxAttempts = 2*params.Beta(), 3
+	p0, p1 := newParties(t, &params)
+	both(t, p0, p1, RunKeyGen)
+
+	t0, t1 := NewMemoryTransport()
+	defer t0.Close()
+	go RunSign(p1, t1, nil)
+	if _, err := RunSign(p0, t0, nil); !errors.Is(err, ErrAttempts) {
+		t.Fatalf("expected ErrAttempts, got %v", err)
+	}
+	if p0.attempt != 3 {
+		t.Errorf("gave up after %d attempts", p0.attempt)
+	}
+}
+
+// Deterministic randomness gives the same transcript twice
+func TestDeterministicRandomness(t *testing.T) {
+	var sigs [2]*Signature
+	for i := range sigs {
+		seed := bytes.Repeat([]byte{1}, SeedSize)
+		d0, _ := kat.NewDRBG(bytes.Repeat([]byte{0}, kat.SeedSize), nil)
+		d1, _ := kat.NewDRBG(bytes.Repeat([]byte{1}, kat.SeedSize), nil)
+		p0, _ := NewParty(testParams, 0, seed, d0)
+		p1, _ := NewParty(testParams, 1, seed, d1)
+		both(t, p0, p1, RunKeyGen)
+		sigs[i], _ = both(t, p0, p1, func(p *Party, tr Transport) (*Signature, error) {
+			return RunSign(p, tr, []byte("message"))
+		})
+	}
+	if !bytes.Equal(sigs[0].CTilde, sigs[1].CTilde) || !sigs[0].Z.Equals(sigs[1].Z) {
+		t.Error("same randomness gave different signatures")
+	}
+}
+
+func TestMessageRoundTrip(t *testing.T) {
+	vec := vector.NewPolyQVectorFromCoeffs([][]int64{{1, -2}, {3}})
+	msgs := []Message{
+		{Type: MsgKeyCommit, From: 1, Commitment: make([]byte, 32)},
+		{Type: MsgReveal, From: 0, Attempt: 7, Nonce: bytes.Repeat([]byte{9}, 32), Vector: vec},
+		{Type: MsgResponse, From: 1, Attempt: 2, Vector: vec},
+		{Type: MsgAbort, From: 0, Attempt: 1},
+	}
+	for _, msg := range msgs {
+		b, err := msg.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Message
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("%v: %v", msg.Type, err)
+		}
+		if got.Type != msg.Type || got.From != msg.From || got.Attempt != msg.Attempt ||
+			!bytes.Equal(got.Commitment, msg.Commitment) || !bytes.Equal(got.Nonce, msg.Nonce) ||
+			(msg.Vector != nil && !got.Vector.Equals(msg.Vector)) {
+			t.Errorf("%v does not round trip", msg.Type)
+		}
+		if err := got.UnmarshalBinary(b[:len(b)-1]); msg.Type != MsgAbort && err == nil {
+			t.Errorf("%v: truncated message accepted", msg.Type)
+		}
+	}
+
+	if _, err := (Message{Type: MsgCommit, Commitment: []byte{1}}).MarshalBinary(); !errors.Is(err, ErrMessage) {
+		t.Errorf("short commitment: %v", err)
+	}
+}
+
+func TestCheating(t *testing.T) {
+	p0, p1 := newParties(t, testParams)
+	c0, _ := p0.StartKeyGen()
+	c1, _ := p1.StartKeyGen()
+	r0, _ := p0.Handle(c1)
+	p1.Handle(c0)
+
+	// A reveal that does not match the commitment
+	r0.Vector = r0.Vector.Add(vector.NewPolyQVectorFromCoeffs([][]int64{{1}, {0}}))
+	if _, err := p1.Handle(r0); !errors.Is(err, ErrOpening) {
+		t.Errorf("changed t_0: %v", err)
+	}
+	if _, err := p1.Handle(r0); !errors.Is(err, ErrState) {
+		t.Errorf("failed party kept going: %v", err)
+	}
+
+	// A rushing peer echoing the commitment and then the reveal of party 0
+	p0, _ = newParties(t, testParams)
+	c0, _ = p0.StartKeyGen()
+	echo := *c0
+	echo.From = 1
+	if _, err := p0.Handle(&echo); !errors.Is(err, ErrOpening) {
+		t.Errorf("echoed commitment: %v", err)
+	}
+	// The replayed reveal does not open under the sender of the echo either
+	if bytes.Equal(commit("twoparty key", p0.seed, 0, 0, p0.tOwn, p0.nonce), commit("twoparty key", p0.seed, 1, 0, p0.tOwn, p0.nonce)) {
+		t.Error("commitment does not bind the sender")
+	}
+
+	// Out of order and replayed messages
+	p0, p1 = newParties(t, testParams)
+	c0, _ = p0.StartKeyGen()
+	if _, err := p1.Handle(c0); !errors.Is(err, ErrState) {
+		t.Errorf("commitment before StartKeyGen: %v", err)
+	}
+	if _, err := p0.StartSign(nil); !errors.Is(err, ErrState) {
+		t.Errorf("signing before key generation: %v", err)
+	}
+
+	// A response that does not match the revealed w_1
+	p0, p1 = newParties(t, testParams)
+	both(t, p0, p1, RunKeyGen)
+	for {
+		m0, _ := p0.StartSign(nil)
+		m1, _ := p1.StartSign(nil)
+		m0, m1 = exchange(t, p0, p1, m0, m1)
+		m0, m1 = exchange(t, p0, p1, m0, m1)
+		if m0.Type == MsgAbort || m1.Type == MsgAbort {
+			p0, p1 = newParties(t, testParams)
+			both(t, p0, p1, RunKeyGen)
+			continue
+		}
+		m1.Vector = m1.Vector.ScaledByInt(-1)
+		if _, err := p0.Handle(m1); !errors.Is(err, ErrResponse) {
+			t.Errorf("forged z_1: %v", err)
+		}
+		break
+	}
+}
+
+// The masks must be uniform, a point mass at 0 would leak c*s_i through z_i
+func TestMaskHistogram(t *testing.T) {
+	p0, _ := newParties(t, testParams)
+	q := latticehelper.MainRing.Modulus().Int64()
+
+	counts := make(map[int64]int)
+	for range 50 {
+		y, err := p0.short(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range y {
+			for _, c := range p.Listize() {
+				counts[poly.CenteredModulo(c, q)]++
+			}
+		}
+	}
+	expected := 50 * (testParams.K + testParams.L) * latticehelper.MainRing.N() / 5
+	for c := int64(-2); c <= 2; c++ {
+		if counts[c] < expected*9/10 || counts[c] > expected*11/10 {
+			t.Errorf("%d drawn %d times, expected about %d", c, counts[c], expected)
+		}
+	}
+
+	// Same for the real masks, split into 4 buckets of [-Gamma, Gamma)
+	p0, p1 := newParties(t, testParams)
+	both(t, p0, p1, RunKeyGen)
+	buckets := make([]int, 4)
+	for range 20 {
+		if _, err := p0.StartSign(nil); err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range p0.y {
+			for _, c := range p.Listize() {
+				c = poly.CenteredModulo(c, q)
+				if c < -testParams.Gamma || c > testParams.Gamma {
+					t.Fatalf("mask coefficient %d", c)
+				}
+				if c < testParams.Gamma {
+					buckets[(c+testParams.Gamma)*4/(2*testParams.Gamma)]++
+				}
+			}
+		}
+		p0.state = stateReady
+	}
+	expected = 20 * (testParams.K + testParams.L) * latticehelper.MainRing.N() / 4
+	for i, n := range buckets {
+		if n < expected*9/10 || n > expected*11/10 {
+			t.Errorf("bucket %d has %d masks, expected about %d", i, n, expected)
+		}
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	p0, p1 := newParties(t, testParams)
+	pk, _ := both(t, p0, p1, RunKeyGen)
+	sig, _ := both(t, p0, p1, func(p *Party, tr Transport) (*Signature, error) {
+		return RunSign(p, tr, []byte("message"))
+	})
+
+	large := vector.NewZeroPolyQVector(testParams.K + testParams.L)
+	large[0] = poly.NewConstantPolyQ(2 * testParams.ResponseBound())
+	cases := map[string]*Signature{
+		"changed z":    {CTilde: sig.CTilde, Z: sig.Z.Add(vector.NewPolyQVectorFromCoeffs([][]int64{{1}, {0}, {0}, {0}}))},
+		"changed c":    {CTilde: bytes.Repeat([]byte{0}, 32), Z: sig.Z},
+		"large z":      {CTilde: sig.CTilde, Z: sig.Z.Add(large)},
+		"short z":      {CTilde: sig.CTilde, Z: sig.Z[1:]},
+		"short c":      {CTilde: sig.CTilde[1:], Z: sig.Z},
+		"no signature": nil,
+	}
+	for name, bad := range cases {
+		if pk.Verify([]byte("message"), bad) {
+			t.Errorf("%s accepted", name)
+		}
+	}
+}
+
+func TestParameters(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	bad := []*Parameters{
+		{K: 0, L: 2, Eta: 1, Tau: 16, Gamma: 1 << 16},
+		{K: 2, L: 2, Eta: 1, Tau: 65, Gamma: 1 << 16},
+		{K: 2, L: 2, Eta: 1, Tau: 16, Gamma: 16},
+		{K: 2, L: 2, Eta: 1, Tau: 16, Gamma: 1 << 31},
+	}
+	for _, params := range bad {
+		if _, err := NewParty(params, 0, seed, nil); !errors.Is(err, ErrParameters) {
+			t.Errorf("%+v: %v", *params, err)
+		}
+	}
+	if _, err := NewParty(testParams, 2, seed, nil); !errors.Is(err, ErrParameters) {
+		t.Errorf("party 2: %v", err)
+	}
+
+	// The challenge has exactly tau nonzero coefficients
+	c := testParams.challenge(make([]byte, 32))
+	if n := c.InfiniteNorm(); n != 1 {
+		t.Errorf("challenge with norm %d", n)
+	}
+	ones := 0
+	for _, coeff := range c.Listize() {
+		if coeff != 0 {
+			ones++
+		}
+	}
+	if ones != testParams.Tau {
+		t.Errorf("challenge with %d nonzero coefficients", ones)
+	}
+}
+
+func TestDefaultParameters(t *testing.T) {
+	latticehelper.InitSingle(256, 8380417)
+	defer latticehelper.InitSingle(128, 4294954753)
+
+	p0, p1 := newParties(t, DefaultParameters)
+	pk, _ := both(t, p0, p1, RunKeyGen)
+	sig, _ := both(t, p0, p1, func(p *Party, tr Transport) (*Signature, error) {
+		return RunSign(p, tr, []byte("message"))
+	})
+	if !pk.Verify([]byte("message"), sig) {
+		t.Error("signature rejected")
+	}
+}
@@ -0,0 +1,233 @@
+package reduction
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/isri-pqc/latticehelper/intmatrix"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+)
+
+// Lattice basis, one basis vector per row
+type Basis [][]int64
+
+// Basis of the SIS lattice {x : A * x = 0 mod q} for prime q. Its
+// determinant is q^rank(A) and its short vectors are SIS solutions.
+func NewSISBasis(a intmatrix.IntMatrix, q int64) Basis {
+	_, pivots := a.RowEchelonMod(q)
+	kernel := a.KernelMod(q)
+
+	ret := make(Basis, 0, a.Cols())
+	for _, col := range pivots {
+		vec := make([]int64, a.Cols())
+		vec[col] = q
+		ret = append(ret, vec)
+	}
+	for _, vec := range kernel {
+		ret = append(ret, centered(vec, q))
+	}
+	return ret
+}
+
+// Basis of the LWE lattice {y : y = A^T * s mod q} for prime q
+func NewLWEBasis(a intmatrix.IntMatrix, q int64) Basis {
+	echelon, pivots := a.RowEchelonMod(q)
+
+	isPivot := make([]bool, a.Cols())
+	for _, col := range pivots {
+		isPivot[col] = true
+	}
+
+	ret := make(Basis, 0, a.Cols())
+	for col := 0; col < a.Cols(); col++ {
+		if !isPivot[col] {
+			vec := make([]int64, a.Cols())
+			vec[col] = q
+			ret = append(ret, vec)
+		}
+	}
+	for row := range pivots {
+		ret = append(ret, centered(echelon[row], q))
+	}
+	return ret
+}
+
+// SIS lattice of a module matrix in its flat view, see PolyQMatrix.IntMatrix
+func NewSISBasisFromPolyQMatrix(a matrix.PolyQMatrix, q int64) Basis {
+	return NewSISBasis(a.IntMatrix(), q)
+}
+
+// LWE lattice of a module matrix in its flat view, see PolyQMatrix.IntMatrix
+func NewLWEBasisFromPolyQMatrix(a matrix.PolyQMatrix, q int64) Basis {
+	return NewLWEBasis(a.IntMatrix(), q)
+}
+
+func centered(vec []int64, q int64) []int64 {
+	ret := make([]int64, len(vec))
+	for i, entry := range vec {
+		entry %= q
+		if entry < 0 {
+			entry += q
+		}
+		if entry > q>>1 {
+			entry -= q
+		}
+		ret[i] = entry
+	}
+	return ret
+}
+
+func (basis Basis) Rank() int {
+	return len(basis)
+}
+
+func (basis Basis) Dim() int {
+	if len(basis) == 0 {
+		return 0
+	}
+	return len(basis[0])
+}
+
+func (basis Basis) Copy() Basis {
+	ret := make(Basis, len(basis))
+	for i, vec := range basis {
+		ret[i] = make([]int64, len(vec))
+		copy(ret[i], vec)
+	}
+	return ret
+}
+
+func (basis Basis) String() string {
+	var sb strings.Builder
+	sb.WriteString("Basis{\n")
+	for _, vec := range basis {
+		sb.WriteString("\t" + fmt.Sprint(vec) + "\n")
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func Norm(vec []int64) float64 {
+	sum := 0.0
+	for _, entry := range vec {
+		sum += float64(entry) * float64(entry)
+	}
+	return math.Sqrt(sum)
+}
+
+// Shortest non-zero basis vector and its Euclidean norm
+func (basis Basis) ShortestVector() ([]int64, float64) {
+	var ret []int64
+	min := math.Inf(1)
+	for _, vec := range basis {
+		if norm := Norm(vec); norm > 0 && norm < min {
+			ret, min = vec, norm
+		}
+	}
+	return ret, min
+}
+
+// Floating-point Gram-Schmidt orthogonalization. Returns the squared norms
+// B_i = |b*_i|^2 and the coefficients mu_ij = <b_i, b*_j> / B_j for j < i.
+func (basis Basis) GramSchmidt() ([]float64, [][]float64) {
+	n := basis.Rank()
+	bstar := make([][]float64, n)
+	bNorms := make([]float64, n)
+	mu := make([][]float64, n)
+
+	for i := 0; i < n; i++ {
+		mu[i] = make([]float64, n)
+		bstar[i], bNorms[i] = gramSchmidtRow(basis[i], bstar[:i], bNorms[:i], mu[i])
+	}
+	return bNorms, mu
+}
+
+func gramSchmidtRow(vec []int64, bstar [][]float64, bNorms []float64, mu []float64) ([]float64, float64) {
+	row := make([]float64, len(vec))
+	for k, entry := range vec {
+		row[k] = float64(entry)
+	}
+
+	for j := range bstar {
+		if bNorms[j] == 0 {
+			mu[j] = 0
+			continue
+		}
+		dot := 0.0
+		for k, entry := range vec {
+			dot += float64(entry) * bstar[j][k]
+		}
+		mu[j] = dot / bNorms[j]
+		for k := range row {
+			row[k] -= mu[j] * bstar[j][k]
+		}
+	}
+	mu[len(bstar)] = 1
+
+	norm := 0.0
+	for _, entry := range row {
+		norm += entry * entry
+	}
+	return row, norm
+}
+
+// Exact Gram-Schmidt over the rationals, the slow reference for GramSchmidt
+func (basis Basis) GramSchmidtExact() ([]*big.Rat, [][]*big.Rat) {
+	n := basis.Rank()
+	bstar := make([][]*big.Rat, n)
+	bNorms := make([]*big.Rat, n)
+	mu := make([][]*big.Rat, n)
+
+	dot := func(a, b []*big.Rat) *big.Rat {
+		ret := new(big.Rat)
+		for k := range a {
+			ret.Add(ret, new(big.Rat).Mul(a[k], b[k]))
+		}
+		return ret
+	}
+
+	for i := 0; i < n; i++ {
+		vec := make([]*big.Rat, basis.Dim())
+		for k, entry := range basis[i] {
+			vec[k] = new(big.Rat).SetInt64(entry)
+		}
+
+		bstar[i] = make([]*big.Rat, basis.Dim())
+		for k := range vec {
+			bstar[i][k] = new(big.Rat).Set(vec[k])
+		}
+
+		mu[i] = make([]*big.Rat, n)
+		for j := 0; j < n; j++ {
+			mu[i][j] = new(big.Rat)
+		}
+		mu[i][i].SetInt64(1)
+
+		for j := 0; j < i; j++ {
+			if bNorms[j].Sign() == 0 {
+				continue
+			}
+			mu[i][j].Quo(dot(vec, bstar[j]), bNorms[j])
+			for k := range bstar[i] {
+				bstar[i][k].Sub(bstar[i][k], new(big.Rat).Mul(mu[i][j], bstar[j][k]))
+			}
+		}
+		bNorms[i] = dot(bstar[i], bstar[i])
+	}
+	return bNorms, mu
+}
+
+// Root Hermite factor (|b_1| / det^(1/n))^(1/n) of a full-rank basis
+func (basis Basis) RootHermiteFactor() float64 {
+	bNorms, _ := basis.GramSchmidt()
+	n := float64(basis.Rank())
+
+	logDet := 0.0
+	for _, b := range bNorms {
+		logDet += 0.5 * math.Log(b)
+	}
+
+	return math.Exp((math.Log(Norm(basis[0])) - logDet/n) / n)
+}
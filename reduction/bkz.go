@@ -0,0 +1,94 @@
+package reduction
+
+import (
+	"math"
+)
+
+// BKZ-reduced copy of the basis. Every tour enumerates the shortest vector
+// of each projected block of size blockSize and inserts it when it is
+// shorter than the current Gram-Schmidt vector; tours repeat until nothing
+// changes. delta is passed on to the LLL calls in between.
+func (basis Basis) BKZ(blockSize int, delta float64) Basis {
+	b := basis.LLL(delta)
+	if blockSize < 2 {
+		return b
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for k := 0; k < len(b)-1; k++ {
+			end := min(k+blockSize, len(b))
+
+			bNorms, mu := b.GramSchmidt()
+			coeffs := enumerate(bNorms, mu, k, end, 0.99*bNorms[k])
+			if coeffs == nil {
+				continue
+			}
+
+			vec := make([]int64, b.Dim())
+			for i, c := range coeffs {
+				for t := range vec {
+					vec[t] += c * b[k+i][t]
+				}
+			}
+
+			inserted := make(Basis, 0, len(b)+1)
+			inserted = append(inserted, b[:k]...)
+			inserted = append(inserted, vec)
+			inserted = append(inserted, b[k:]...)
+
+			b = inserted.LLL(delta)
+			changed = true
+		}
+	}
+
+	return b
+}
+
+// Schnorr-Euchner enumeration of the shortest non-zero vector in the
+// projection of the block [start, end) orthogonally to b_0 .. b_(start-1).
+// Returns its coefficients in the block basis, or nil when no vector is
+// shorter than radius (squared norm).
+func enumerate(bNorms []float64, mu [][]float64, start, end int, radius float64) []int64 {
+	dim := end - start
+	x := make([]int64, dim)
+	var best []int64
+
+	var search func(i int, partial float64)
+	search = func(i int, partial float64) {
+		center := 0.0
+		for j := i + 1; j < dim; j++ {
+			center -= float64(x[j]) * mu[start+j][start+i]
+		}
+		rounded := math.Round(center)
+
+		try := func(xi float64) bool {
+			diff := xi - center
+			dist := partial + diff*diff*bNorms[start+i]
+			if dist >= radius {
+				return false
+			}
+
+			x[i] = int64(xi)
+			if i > 0 {
+				search(i-1, dist)
+			} else if dist > 1e-9 {
+				best = make([]int64, dim)
+				copy(best, x)
+				radius = dist
+			}
+			return true
+		}
+
+		for xi := rounded; try(xi); xi++ {
+		}
+		for xi := rounded - 1; try(xi); xi-- {
+		}
+		x[i] = 0
+	}
+
+	search(dim-1, 0)
+
+	return best
+}
@@ -0,0 +1,115 @@
+package reduction
+
+import (
+	"math"
+)
+
+// LLL-reduced copy of the basis with Lovasz parameter delta in (1/4, 1),
+// 0.99 being the usual choice. Gram-Schmidt data is kept in floating point
+// and recomputed for the current row after every change (Schnorr-Euchner),
+// which is fine for the toy dimensions this package is meant for.
+// Linearly dependent inputs are accepted, zero vectors are dropped.
+func (basis Basis) LLL(delta float64) Basis {
+	b := removeZeroVectors(basis.Copy())
+	if len(b) == 0 {
+		return b
+	}
+
+	dim := b.Dim()
+	n := len(b)
+
+	bstar := make([][]float64, n)
+	bNorms := make([]float64, n)
+	mu := make([][]float64, n)
+	for i := range mu {
+		mu[i] = make([]float64, n)
+	}
+
+	bstar[0], bNorms[0] = gramSchmidtRow(b[0], nil, nil, mu[0])
+
+	k := 1
+	for k < len(b) {
+		bstar[k], bNorms[k] = gramSchmidtRow(b[k], bstar[:k], bNorms[:k], mu[k])
+
+		// Repeat size reduction until stable, floating point errors may
+		// leave coefficients slightly above one half after a single pass
+		for iter := 0; iter < 10; iter++ {
+			reduced := false
+			for j := k - 1; j >= 0; j-- {
+				r := math.Round(mu[k][j])
+				if r == 0 {
+					continue
+				}
+				reduced = true
+
+				factor := int64(r)
+				for t := 0; t < dim; t++ {
+					b[k][t] -= factor * b[j][t]
+				}
+				for t := 0; t <= j; t++ {
+					mu[k][t] -= r * mu[j][t]
+				}
+			}
+			if !reduced {
+				break
+			}
+			bstar[k], bNorms[k] = gramSchmidtRow(b[k], bstar[:k], bNorms[:k], mu[k])
+		}
+
+		if isZero(b[k]) {
+			b = append(b[:k], b[k+1:]...)
+			continue
+		}
+
+		if bNorms[k] >= (delta-mu[k][k-1]*mu[k][k-1])*bNorms[k-1] {
+			k++
+			continue
+		}
+
+		b[k], b[k-1] = b[k-1], b[k]
+		if k > 1 {
+			k--
+		} else {
+			bstar[0], bNorms[0] = gramSchmidtRow(b[0], nil, nil, mu[0])
+		}
+	}
+
+	return b
+}
+
+func isZero(vec []int64) bool {
+	for _, entry := range vec {
+		if entry != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func removeZeroVectors(basis Basis) Basis {
+	ret := basis[:0]
+	for _, vec := range basis {
+		if !isZero(vec) {
+			ret = append(ret, vec)
+		}
+	}
+	return ret
+}
+
+// Checks size reduction |mu_ij| <= 1/2 + eps and the Lovasz condition
+func (basis Basis) IsLLLReduced(delta float64) bool {
+	bNorms, mu := basis.GramSchmidt()
+	const eps = 1e-6
+
+	for i := 1; i < basis.Rank(); i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(mu[i][j]) > 0.5+eps {
+				return false
+			}
+		}
+		if bNorms[i] < (delta-mu[i][i-1]*mu[i][i-1])*bNorms[i-1]*(1-eps) {
+			return false
+		}
+	}
+	return true
+}
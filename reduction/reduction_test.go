@@ -0,0 +1,148 @@
+package reduction
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+)
+
+const q = 12289
+
+func TestMain(m *testing.M) {
+	// Small ring so that the flattened lattices stay in toy dimensions
+	latticehelper.InitSingle(16, q)
+	m.Run()
+}
+
+func absDeterminant(basis Basis) *big.Rat {
+	bNorms, _ := basis.GramSchmidtExact()
+	ret := big.NewRat(1, 1)
+	for _, b := range bNorms {
+		ret.Mul(ret, b)
+	}
+	return ret
+}
+
+func TestGramSchmidtMatchesExact(t *testing.T) {
+	basis := Basis{{3, 1, 4}, {1, 5, 9}, {2, 6, 5}}
+
+	bNorms, mu := basis.GramSchmidt()
+	exactNorms, exactMu := basis.GramSchmidtExact()
+
+	for i := range bNorms {
+		expected, _ := exactNorms[i].Float64()
+		if math.Abs(bNorms[i]-expected) > 1e-9*expected {
+			t.Errorf("B_%d: expected %v but got %v", i, expected, bNorms[i])
+		}
+		for j := 0; j < i; j++ {
+			expected, _ := exactMu[i][j].Float64()
+			if math.Abs(mu[i][j]-expected) > 1e-9 {
+				t.Errorf("mu_%d%d: expected %v but got %v", i, j, expected, mu[i][j])
+			}
+		}
+	}
+}
+
+func TestLLL(t *testing.T) {
+	basis := Basis{{1, 1, 1}, {-1, 0, 2}, {3, 5, 6}}
+	reduced := basis.LLL(0.75)
+
+	if !reduced.IsLLLReduced(0.75) {
+		t.Errorf("Basis is not LLL reduced: %v", reduced)
+	}
+	if absDeterminant(reduced).Cmp(absDeterminant(basis)) != 0 {
+		t.Error("LLL changed the lattice determinant")
+	}
+	if _, norm := reduced.ShortestVector(); norm != 1 {
+		t.Errorf("Expected shortest vector of norm 1 but got %v", norm)
+	}
+}
+
+func TestLLLDropsDependentVectors(t *testing.T) {
+	basis := Basis{{2, 0}, {0, 3}, {4, 9}}
+	reduced := basis.LLL(0.99)
+
+	if reduced.Rank() != 2 {
+		t.Fatalf("Expected rank 2 but got %d", reduced.Rank())
+	}
+	if absDeterminant(reduced).Cmp(big.NewRat(36, 1)) != 0 {
+		t.Errorf("Expected squared determinant 36 but got %v", absDeterminant(reduced))
+	}
+}
+
+func TestSISLatticeReduction(t *testing.T) {
+	a := matrix.NewRandomPolyQMatrix(nil, 1, 2)
+	flat := a.IntMatrix()
+
+	basis := NewSISBasisFromPolyQMatrix(a, q)
+	if basis.Rank() != flat.Cols() {
+		t.Fatalf("Expected full rank basis but got rank %d", basis.Rank())
+	}
+
+	lll := basis.LLL(0.99)
+	bkz := basis.BKZ(10, 0.99)
+
+	for _, reduced := range []Basis{lll, bkz} {
+		if reduced.Rank() != basis.Rank() {
+			t.Fatalf("Reduction changed the rank to %d", reduced.Rank())
+		}
+
+		shortest, norm := reduced.ShortestVector()
+		if norm >= q {
+			t.Errorf("Expected a vector shorter than q but got %v", norm)
+		}
+		for _, entry := range flat.VecMulMod(shortest, q) {
+			if entry != 0 {
+				t.Fatal("Shortest vector is not in the SIS lattice")
+			}
+		}
+	}
+
+	if Norm(bkz[0]) > Norm(lll[0]) {
+		t.Errorf("BKZ found a longer first vector (%v) than LLL (%v)", Norm(bkz[0]), Norm(lll[0]))
+	}
+}
+
+func TestLWELatticeContainsImage(t *testing.T) {
+	a := matrix.NewRandomPolyQMatrix(nil, 1, 2)
+	flat := a.IntMatrix()
+
+	basis := NewLWEBasis(flat, q)
+	if absDeterminant(basis).Cmp(absDeterminant(basis.LLL(0.99))) != 0 {
+		t.Error("LLL changed the lattice determinant")
+	}
+
+	// q^(2*(n-m)) with n = 32 columns and rank m = 16
+	expected := new(big.Int).Exp(big.NewInt(q), big.NewInt(2*16), nil)
+	if absDeterminant(basis).Cmp(new(big.Rat).SetInt(expected)) != 0 {
+		t.Error("Unexpected LWE lattice determinant")
+	}
+}
+
+func TestBKZOnRandomLattice(t *testing.T) {
+	basis := make(Basis, 20)
+	for i := range basis {
+		basis[i] = make([]int64, 20)
+		basis[i][i] = 1
+		basis[i][0] = rand.Int63n(100003)
+	}
+	basis[0] = make([]int64, 20)
+	basis[0][0] = 100003
+
+	lll := basis.LLL(0.99)
+	bkz := basis.BKZ(8, 0.99)
+
+	if !bkz.IsLLLReduced(0.99) {
+		t.Error("BKZ output is not LLL reduced")
+	}
+	if Norm(bkz[0]) > Norm(lll[0]) {
+		t.Errorf("BKZ found a longer first vector (%v) than LLL (%v)", Norm(bkz[0]), Norm(lll[0]))
+	}
+	if bkz.RootHermiteFactor() > lll.RootHermiteFactor()+1e-12 {
+		t.Error("BKZ root Hermite factor is worse than LLL")
+	}
+}
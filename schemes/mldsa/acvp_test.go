@@ -0,0 +1,95 @@
+package mldsa
+
+import (
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/kat"
+)
+
+// ACVP vectors of NIST for the internal functions. See the comments at the
+// top of the files for the source.
+func acvpRecords(t *testing.T, path string, count int) []*kat.Record {
+	f, err := kat.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Records) != count {
+		t.Fatalf("%s: %d vectors", path, len(f.Records))
+	}
+	return f.Records
+}
+
+func acvpParams(t *testing.T, rec *kat.Record) *Parameters {
+	name, _ := rec.Value("parameterSet")
+	for _, params := range allParams {
+		if params.Name == name {
+			return params
+		}
+	}
+	t.Fatalf("count %d: unknown parameter set %q", rec.Count, name)
+	return nil
+}
+
+func TestACVPKeyGen(t *testing.T) {
+	for _, rec := range acvpRecords(t, "testdata/ML-DSA-keyGen.rsp", 30) {
+		seed, _ := rec.Bytes("seed")
+		pk, sk, err := NewKeyFromSeed(acvpParams(t, rec), seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rec.Check("pk", pk.Bytes()); err != nil {
+			t.Error(err)
+		}
+		if err := rec.Check("sk", sk.Bytes()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// An empty rnd is the deterministic variant
+func TestACVPSigGen(t *testing.T) {
+	for _, rec := range acvpRecords(t, "testdata/ML-DSA-sigGen.rsp", 30) {
+		b, _ := rec.Bytes("sk")
+		sk, err := ParsePrivateKey(acvpParams(t, rec), b)
+		if err != nil {
+			t.Fatalf("count %d: %v", rec.Count, err)
+		}
+		message, _ := rec.Bytes("message")
+		rnd, _ := rec.Bytes("rnd")
+		if len(rnd) == 0 {
+			rnd = make([]byte, 32)
+		}
+		if err := rec.Check("signature", sk.signInternal(message, rnd)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// Most vectors are rejections, 9 of them of hints that decodeSignature
+// refuses, the others of changed messages and signatures
+func TestACVPSigVer(t *testing.T) {
+	rejected, malformed := 0, 0
+	for _, rec := range acvpRecords(t, "testdata/ML-DSA-sigVer.rsp", 45) {
+		b, _ := rec.Bytes("pk")
+		pk, err := ParsePublicKey(acvpParams(t, rec), b)
+		if err != nil {
+			t.Fatalf("count %d: %v", rec.Count, err)
+		}
+		message, _ := rec.Bytes("message")
+		signature, _ := rec.Bytes("signature")
+		expected, _ := rec.Value("testPassed")
+
+		if got := pk.verifyInternal(message, signature); got != (expected == "true") {
+			t.Errorf("count %d: verification gave %v, want %s", rec.Count, got, expected)
+		}
+		if _, _, _, ok := pk.Params.decodeSignature(signature); !ok {
+			malformed++
+		}
+		if expected == "false" {
+			rejected++
+		}
+	}
+	if rejected != 36 || malformed != 9 {
+		t.Errorf("%d rejections, %d malformed signatures", rejected, malformed)
+	}
+}
@@ -0,0 +1,167 @@
+package mldsa
+
+import (
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+const (
+	// t1 has bitlen(q - 1) - d = 10 bits per coefficient
+	t1Bits = 10
+	t1Size = N * t1Bits / 8
+	t0Size = N * D / 8
+)
+
+func (params *Parameters) etaSize() int {
+	return poly.PackedSize(bits.Len64(uint64(2 * params.Eta)))
+}
+
+func (params *Parameters) zSize() int {
+	return poly.PackedSize(1 + bits.Len64(uint64(params.Gamma1-1)))
+}
+
+// Bits per coefficient of w1, whose coefficients are below (q - 1) / (2 gamma2)
+func (params *Parameters) w1Bits() int {
+	return bits.Len64(uint64((Q-1)/(2*params.Gamma2) - 1))
+}
+
+// Algorithm 22, pkEncode
+func encodePublicKey(rho []byte, t1 vector.PolyQVector) []byte {
+	packed, err := t1.PackBits(t1Bits)
+	if err != nil {
+		panic(err)
+	}
+	return append(append([]byte(nil), rho...), packed...)
+}
+
+// Algorithm 23, pkDecode
+func (params *Parameters) decodePublicKey(pk []byte) (rho []byte, t1 vector.PolyQVector, err error) {
+	if len(pk) != params.PublicKeySize() {
+		return nil, nil, ErrKey
+	}
+	if err := t1.UnpackBits(pk[32:], t1Bits); err != nil {
+		return nil, nil, ErrKey
+	}
+	return pk[:32], t1, nil
+}
+
+// Algorithm 24, skEncode
+func (params *Parameters) encodePrivateKey(sk *PrivateKey) []byte {
+	b := make([]byte, 0, params.PrivateKeySize())
+	b = append(b, sk.Rho...)
+	b = append(b, sk.Key...)
+	b = append(b, sk.Tr...)
+	for _, part := range []struct {
+		vec    vector.PolyQVector
+		lo, hi int64
+	}{
+		{sk.S1, -params.Eta, params.Eta},
+		{sk.S2, -params.Eta, params.Eta},
+		{sk.T0, -(1<<(D-1) - 1), 1 << (D - 1)},
+	} {
+		packed, err := part.vec.PackBounded(part.lo, part.hi)
+		if err != nil {
+			panic(err)
+		}
+		b = append(b, packed...)
+	}
+	return b
+}
+
+// Algorithm 25, skDecode. Coefficients of s1 and s2 outside [-eta, eta]
+// are rejected.
+func (params *Parameters) decodePrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != params.PrivateKeySize() {
+		return nil, ErrKey
+	}
+
+	sk := &PrivateKey{Params: params, Rho: b[:32], Key: b[32:64], Tr: b[64:128]}
+	rest := b[128:]
+
+	s1Size, s2Size := params.L*params.etaSize(), params.K*params.etaSize()
+	if err := sk.S1.UnpackBounded(rest[:s1Size], -params.Eta, params.Eta); err != nil {
+		return nil, ErrKey
+	}
+	if err := sk.S2.UnpackBounded(rest[s1Size:s1Size+s2Size], -params.Eta, params.Eta); err != nil {
+		return nil, ErrKey
+	}
+	if err := sk.T0.UnpackBounded(rest[s1Size+s2Size:], -(1<<(D-1) - 1), 1<<(D-1)); err != nil {
+		return nil, ErrKey
+	}
+	return sk, nil
+}
+
+// Algorithm 26, sigEncode
+func (params *Parameters) encodeSignature(cTilde []byte, z, hint vector.PolyQVector) []byte {
+	b := make([]byte, 0, params.SignatureSize())
+	b = append(b, cTilde...)
+
+	packed, err := z.PackBounded(-(params.Gamma1 - 1), params.Gamma1)
+	if err != nil {
+		panic(err)
+	}
+	b = append(b, packed...)
+
+	// Algorithm 20, HintBitPack
+	y := make([]byte, params.Omega+params.K)
+	index := 0
+	for i, p := range hint {
+		for j, coeff := range p.Listize() {
+			if coeff != 0 {
+				y[index] = byte(j)
+				index++
+			}
+		}
+		y[params.Omega+i] = byte(index)
+	}
+	return append(b, y...)
+}
+
+// Algorithm 27, sigDecode. ok is false for a malformed hint.
+func (params *Parameters) decodeSignature(sig []byte) (cTilde []byte, z, hint vector.PolyQVector, ok bool) {
+	if len(sig) != params.SignatureSize() {
+		return nil, nil, nil, false
+	}
+
+	cTilde = sig[:params.Lambda/4]
+	zEnd := len(cTilde) + params.L*params.zSize()
+	if err := z.UnpackBounded(sig[len(cTilde):zEnd], -(params.Gamma1 - 1), params.Gamma1); err != nil {
+		return nil, nil, nil, false
+	}
+
+	// Algorithm 21, HintBitUnpack
+	y := sig[zEnd:]
+	hint = make(vector.PolyQVector, params.K)
+	index := 0
+	for i := range hint {
+		coeffs := make([]int64, N)
+		end := int(y[params.Omega+i])
+		if end < index || end > params.Omega {
+			return nil, nil, nil, false
+		}
+		for first := index; index < end; index++ {
+			if index > first && y[index-1] >= y[index] {
+				return nil, nil, nil, false
+			}
+			coeffs[y[index]] = 1
+		}
+		hint[i] = poly.NewPolyQFromCoeffs(coeffs...)
+	}
+	for ; index < params.Omega; index++ {
+		if y[index] != 0 {
+			return nil, nil, nil, false
+		}
+	}
+	return cTilde, z, hint, true
+}
+
+// Algorithm 28, w1Encode
+func (params *Parameters) EncodeW1(w1 vector.PolyQVector) []byte {
+	packed, err := w1.PackBits(params.w1Bits())
+	if err != nil {
+		panic(err)
+	}
+	return packed
+}
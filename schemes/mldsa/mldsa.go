@@ -0,0 +1,229 @@
+package mldsa
+
+import (
+	"crypto/subtle"
+	"io"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+type PublicKey struct {
+	Params *Parameters
+	Rho    []byte
+	T1     vector.PolyQVector
+}
+
+type PrivateKey struct {
+	Params *Parameters
+	Rho    []byte
+	Key    []byte
+	// H(pk), 64 bytes
+	Tr     []byte
+	S1, S2 vector.PolyQVector
+	T0     vector.PolyQVector
+}
+
+// Algorithm 1 with randomness from rand, crypto/rand if nil
+func GenerateKey(params *Parameters, rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(latticehelper.RandReader(rand), seed); err != nil {
+		return nil, nil, err
+	}
+	return NewKeyFromSeed(params, seed)
+}
+
+// Algorithm 6, ML-DSA.KeyGen_internal(xi)
+func NewKeyFromSeed(params *Parameters, xi []byte) (*PublicKey, *PrivateKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, nil, err
+	}
+	if len(xi) != SeedSize {
+		return nil, nil, ErrKey
+	}
+
+	expanded := h(128, xi, []byte{byte(params.K), byte(params.L)})
+	rho, rhoPrime, key := expanded[:32], expanded[32:96], expanded[96:]
+
+	a := params.ExpandA(rho)
+	s1, s2 := params.ExpandS(rhoPrime)
+	t := a.VecMul(s1).Add(s2)
+	t1, t0 := t.Power2Round(D)
+
+	pk := &PublicKey{Params: params, Rho: rho, T1: t1}
+	sk := &PrivateKey{Params: params, Rho: rho, Key: key, Tr: h(64, pk.Bytes()), S1: s1, S2: s2, T0: t0}
+	return pk, sk, nil
+}
+
+// Algorithm 22, pkEncode
+func (pk *PublicKey) Bytes() []byte {
+	return encodePublicKey(pk.Rho, pk.T1)
+}
+
+// Algorithm 24, skEncode
+func (sk *PrivateKey) Bytes() []byte {
+	return sk.Params.encodePrivateKey(sk)
+}
+
+func ParsePublicKey(params *Parameters, b []byte) (*PublicKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	rho, t1, err := params.decodePublicKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &PublicKey{Params: params, Rho: append([]byte(nil), rho...), T1: t1}, nil
+}
+
+func ParsePrivateKey(params *Parameters, b []byte) (*PrivateKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	return params.decodePrivateKey(append([]byte(nil), b...))
+}
+
+// M' = 0 || len(ctx) || ctx || M, the pure ML-DSA message encoding
+func encodeMessage(message, context []byte) ([]byte, error) {
+	if len(context) > 255 {
+		return nil, ErrContext
+	}
+	mPrime := make([]byte, 0, 2+len(context)+len(message))
+	mPrime = append(mPrime, 0, byte(len(context)))
+	mPrime = append(mPrime, context...)
+	return append(mPrime, message...), nil
+}
+
+// Algorithm 2, hedged signing with 32 bytes from rand, crypto/rand if nil
+func (sk *PrivateKey) Sign(rand io.Reader, message, context []byte) ([]byte, error) {
+	rnd := make([]byte, 32)
+	if _, err := io.ReadFull(latticehelper.RandReader(rand), rnd); err != nil {
+		return nil, err
+	}
+	return sk.sign(message, context, rnd)
+}
+
+// Deterministic variant of Algorithm 2, rnd is all zeros
+func (sk *PrivateKey) SignDeterministic(message, context []byte) ([]byte, error) {
+	return sk.sign(message, context, make([]byte, 32))
+}
+
+func (sk *PrivateKey) sign(message, context, rnd []byte) ([]byte, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	mPrime, err := encodeMessage(message, context)
+	if err != nil {
+		return nil, err
+	}
+	return sk.signInternal(mPrime, rnd), nil
+}
+
+// Algorithm 7, ML-DSA.Sign_internal
+func (sk *PrivateKey) signInternal(mPrime, rnd []byte) []byte {
+	params := sk.Params
+	a := matrix.Precompute(params.ExpandA(sk.Rho))
+
+	mu := h(64, sk.Tr, mPrime)
+	rhoPrime := h(64, sk.Key, rnd, mu)
+
+	for kappa := 0; ; kappa += params.L {
+		y := params.ExpandMask(rhoPrime, kappa)
+		w := a.MulVecNew(y)
+		w1 := w.HighBits(2 * params.Gamma2)
+
+		cTilde := h(params.Lambda/4, mu, params.EncodeW1(w1))
+		c := params.SampleInBall(cTilde)
+
+		z := y.Add(sk.S1.ScaledByPolyQ(c))
+		wMinusCS2 := w.Sub(sk.S2.ScaledByPolyQ(c))
+		r0 := wMinusCS2.LowBits(2 * params.Gamma2)
+		if z.InfiniteNorm() >= params.Gamma1-params.Beta || r0.InfiniteNorm() >= params.Gamma2-params.Beta {
+			continue
+		}
+
+		ct0 := sk.T0.ScaledByPolyQ(c)
+		hint, ones := params.MakeHint(ct0.ScaledByInt(-1), wMinusCS2.Add(ct0))
+		if ct0.InfiniteNorm() >= params.Gamma2 || ones > params.Omega {
+			continue
+		}
+		return params.encodeSignature(cTilde, z, hint)
+	}
+}
+
+// Algorithm 3. False for a malformed signature or a context longer than 255 bytes.
+func (pk *PublicKey) Verify(message, signature, context []byte) bool {
+	if checkRing() != nil {
+		return false
+	}
+	mPrime, err := encodeMessage(message, context)
+	if err != nil {
+		return false
+	}
+	return pk.verifyInternal(mPrime, signature)
+}
+
+// Algorithm 8, ML-DSA.Verify_internal
+func (pk *PublicKey) verifyInternal(mPrime, signature []byte) bool {
+	params := pk.Params
+	cTilde, z, hint, ok := params.decodeSignature(signature)
+	if !ok || z.InfiniteNorm() >= params.Gamma1-params.Beta {
+		return false
+	}
+
+	a := params.ExpandA(pk.Rho)
+	mu := h(64, h(64, pk.Bytes()), mPrime)
+	c := params.SampleInBall(cTilde)
+
+	wApprox := a.VecMul(z).Sub(pk.T1.ScaledByInt(1 << D).ScaledByPolyQ(c))
+	w1 := params.UseHint(hint, wApprox)
+
+	return subtle.ConstantTimeCompare(cTilde, h(params.Lambda/4, mu, params.EncodeW1(w1))) == 1
+}
+
+// Algorithm 39 on every coefficient: 1 where adding z changes the high
+// bits of r. Also returns the number of ones.
+func (params *Parameters) MakeHint(z, r vector.PolyQVector) (vector.PolyQVector, int) {
+	alpha := 2 * params.Gamma2
+	r1, v1 := r.HighBits(alpha), r.Add(z).HighBits(alpha)
+
+	hint := make(vector.PolyQVector, r.Length())
+	ones := 0
+	for i := range hint {
+		a, b := r1[i].Listize(), v1[i].Listize()
+		coeffs := make([]int64, N)
+		for j := range coeffs {
+			if a[j] != b[j] {
+				coeffs[j] = 1
+				ones++
+			}
+		}
+		hint[i] = poly.NewPolyQFromCoeffs(coeffs...)
+	}
+	return hint, ones
+}
+
+// Algorithm 40 on every coefficient: the high bits of r corrected by hint
+func (params *Parameters) UseHint(hint, r vector.PolyQVector) vector.PolyQVector {
+	alpha := 2 * params.Gamma2
+	m := (Q - 1) / alpha
+	r1, r0 := r.HighBits(alpha), r.LowBits(alpha)
+
+	ret := make(vector.PolyQVector, r.Length())
+	for i := range ret {
+		h, high, low := hint[i].Listize(), r1[i].Listize(), r0[i].Listize()
+		coeffs := make([]int64, N)
+		for j := range coeffs {
+			coeffs[j] = high[j]
+			if h[j] == 1 && poly.CenteredModulo(low[j], Q) > 0 {
+				coeffs[j] = latticehelper.PositiveMod(high[j]+1, m)
+			} else if h[j] == 1 {
+				coeffs[j] = latticehelper.PositiveMod(high[j]-1, m)
+			}
+		}
+		ret[i] = poly.NewPolyQFromCoeffs(coeffs...)
+	}
+	return ret
+}
@@ -0,0 +1,170 @@
+package mldsa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+var allParams = []*Parameters{MLDSA44, MLDSA65, MLDSA87}
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(N, Q)
+	m.Run()
+}
+
+func TestNTTRoundTrip(t *testing.T) {
+	ones := make([]int64, N)
+	for i := range ones {
+		ones[i] = 1
+	}
+	if !fromNTT(ones).Equals(poly.NewConstantPolyQ(1)) {
+		t.Error("NTT of 1 is not all ones")
+	}
+
+	p := poly.NewRandomPolyQ(nil)
+	if !fromNTT(toNTT(p)).Equals(p) {
+		t.Error("fromNTT does not invert toNTT")
+	}
+
+	// Pointwise products in the FIPS 204 NTT domain are ring products
+	a, b := toNTT(p), toNTT(poly.NewPolyQFromCoeffs(0, 1))
+	for i := range a {
+		a[i] = a[i] * b[i] % Q
+	}
+	if !fromNTT(a).Equals(p.Mul(poly.NewPolyQFromCoeffs(0, 1))) {
+		t.Error("NTT product differs from PolyQ.Mul")
+	}
+}
+
+// SHA-256 of the public key, private key and deterministic signature of
+// "latticehelper" for the all-zero seed. Public keys and signatures agree
+// with crypto/mldsa, see stdlib_test.go, the NIST vectors are in acvp_test.go.
+func TestKnownAnswers(t *testing.T) {
+	expected := map[*Parameters][3]string{
+		MLDSA44: {
+			"eb4e7302842153b0fa19e8620739ad258af4929c26dd89079a7ec7d4282208e1",
+			"0f9086044d77b6d610c7e92418d9f70a398c69febc7e99f8254aaea98dcfbe77",
+			"89e5a8b95d273b3b3d6b86d4e2b93e5458f832971fda7267582dcc2a55a8901d",
+		},
+		MLDSA65: {
+			"085ba380ff386dd52e42349c6eb88489d6058ea541a4e3fb0dce9a3fd1f7a911",
+			"cfcb5e7edf4348f712b7002b0553d28929856936c98e4adf172e51d5c9934262",
+			"41f4ff15af13ad5905b0fd9387edab0409dc74e2f766cbcc91249b3b29c94f7d",
+		},
+		MLDSA87: {
+			"1d4a461707fc50a7ec93a9c02454778a8b82321ca460eea345e7bbfaff38a3aa",
+			"370d670bcc5cac393d9c6a6d8f784b418a313280c9d3247d305ae18dac8aef75",
+			"5979f1954a1c4c71bbd6f5babe53b05c8e0e1fc054ffb5d4986d3003c6de1747",
+		},
+	}
+
+	for params, hashes := range expected {
+		pk, sk, err := NewKeyFromSeed(params, make([]byte, SeedSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, _ := sk.SignDeterministic([]byte("latticehelper"), nil)
+
+		for i, b := range [][]byte{pk.Bytes(), sk.Bytes(), sig} {
+			if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != hashes[i] {
+				t.Errorf("%v: known answer %d differs", params, i)
+			}
+		}
+	}
+}
+
+func TestSizes(t *testing.T) {
+	expected := map[string][3]int{
+		"ML-DSA-44": {1312, 2560, 2420},
+		"ML-DSA-65": {1952, 4032, 3309},
+		"ML-DSA-87": {2592, 4896, 4627},
+	}
+	for _, params := range allParams {
+		pk, sk, err := GenerateKey(params, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := sk.Sign(nil, []byte("message"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sizes := [3]int{len(pk.Bytes()), len(sk.Bytes()), len(sig)}
+		if sizes != expected[params.Name] || sizes != [3]int{params.PublicKeySize(), params.PrivateKeySize(), params.SignatureSize()} {
+			t.Errorf("%v: sizes %v", params, sizes)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, params := range allParams {
+		pk, sk, _ := NewKeyFromSeed(params, bytes.Repeat([]byte{7}, SeedSize))
+		msg, ctx := []byte("attack at dawn"), []byte("ctx")
+
+		sig, err := sk.Sign(nil, msg, ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pk.Verify(msg, sig, ctx) {
+			t.Fatalf("%v: valid signature rejected", params)
+		}
+
+		tampered := append([]byte(nil), sig...)
+		tampered[len(tampered)/2] ^= 1
+		cases := map[string]bool{
+			"message":   pk.Verify([]byte("attack at dusk"), sig, ctx),
+			"context":   pk.Verify(msg, sig, nil),
+			"signature": pk.Verify(msg, tampered, ctx),
+			"truncated": pk.Verify(msg, sig[:len(sig)-1], ctx),
+		}
+		for name, accepted := range cases {
+			if accepted {
+				t.Errorf("%v: signature with a changed %s accepted", params, name)
+			}
+		}
+
+		// Keys survive encoding
+		pk2, err := ParsePublicKey(params, pk.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk2, err := ParsePrivateKey(params, sk.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig1, _ := sk.SignDeterministic(msg, ctx)
+		sig2, _ := sk2.SignDeterministic(msg, ctx)
+		if !bytes.Equal(sig1, sig2) || !pk2.Verify(msg, sig2, ctx) {
+			t.Errorf("%v: decoded keys behave differently", params)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	_, sk, _ := GenerateKey(MLDSA44, nil)
+	if _, err := sk.Sign(nil, nil, make([]byte, 256)); !errors.Is(err, ErrContext) {
+		t.Errorf("long context: %v", err)
+	}
+	if _, err := ParsePublicKey(MLDSA65, make([]byte, MLDSA44.PublicKeySize())); !errors.Is(err, ErrKey) {
+		t.Errorf("public key of the wrong size: %v", err)
+	}
+
+	// s1 coefficients are 3 bits for eta = 2, 7 decodes to -5
+	b := sk.Bytes()
+	b[128] |= 0x07
+	if _, err := ParsePrivateKey(MLDSA44, b); !errors.Is(err, ErrKey) {
+		t.Errorf("s1 out of range: %v", err)
+	}
+
+	latticehelper.InitSingle(128, 12289)
+	defer latticehelper.InitSingle(N, Q)
+	if _, _, err := GenerateKey(MLDSA44, nil); !errors.Is(err, ErrRing) {
+		t.Errorf("wrong ring: %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package mldsa
+
+import (
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+// zetas[k] = 1753^BitRev8(k) mod q, the twiddle factors of the FIPS 204 NTT
+var zetas = func() [N]int64 {
+	var ret [N]int64
+	for k := range ret {
+		ret[k] = powMod(1753, int64(bits.Reverse8(uint8(k))))
+	}
+	return ret
+}()
+
+func powMod(base, exp int64) int64 {
+	ret := int64(1)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			ret = ret * base % Q
+		}
+		base = base * base % Q
+	}
+	return ret
+}
+
+// The matrix A is sampled in the NTT representation of FIPS 204 (Algorithm
+// 41), which is not the one of lattigo. Converting it back with Algorithm 42
+// lets the rest of the scheme use the ring arithmetic of PolyQ.
+func fromNTT(w []int64) poly.PolyQ {
+	w = append([]int64(nil), w...)
+
+	m := N
+	for length := 1; length < N; length *= 2 {
+		for start := 0; start < N; start += 2 * length {
+			m--
+			z := Q - zetas[m]
+			for j := start; j < start+length; j++ {
+				t := w[j]
+				w[j] = (t + w[j+length]) % Q
+				w[j+length] = (t - w[j+length] + Q) % Q * z % Q
+			}
+		}
+	}
+
+	// 256^-1 mod q
+	const f = 8347681
+	for j := range w {
+		w[j] = w[j] * f % Q
+	}
+	return poly.NewPolyQFromCoeffs(w...)
+}
+
+// Algorithm 41, only used to check fromNTT
+func toNTT(p poly.PolyQ) []int64 {
+	w := p.Listize()
+
+	m := 0
+	for length := N / 2; length >= 1; length /= 2 {
+		for start := 0; start < N; start += 2 * length {
+			m++
+			z := zetas[m]
+			for j := start; j < start+length; j++ {
+				t := z * w[j+length] % Q
+				w[j+length] = (w[j] - t + Q) % Q
+				w[j] = (w[j] + t) % Q
+			}
+		}
+	}
+	return w
+}
@@ -0,0 +1,71 @@
+// Package mldsa implements ML-DSA (FIPS 204) on the ring types of
+// latticehelper. latticehelper.MainRing must be Z_q[X]/(X^256 + 1) with
+// q = 8380417, set up with latticehelper.InitSingle(mldsa.N, mldsa.Q).
+//
+// Only pure ML-DSA is covered, HashML-DSA is not.
+package mldsa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+const (
+	N = 256
+	Q = 8380417
+	// Bits dropped from t by Power2Round
+	D = 13
+
+	SeedSize = 32
+)
+
+var (
+	ErrRing    = errors.New("mldsa: latticehelper.MainRing is not the ML-DSA ring")
+	ErrKey     = errors.New("mldsa: malformed key")
+	ErrContext = errors.New("mldsa: context longer than 255 bytes")
+)
+
+type Parameters struct {
+	Name   string
+	K, L   int
+	Eta    int64
+	Tau    int
+	Beta   int64
+	Gamma1 int64
+	Gamma2 int64
+	Omega  int
+	// Collision strength, the commitment hash c~ has Lambda/4 bytes
+	Lambda int
+}
+
+var (
+	MLDSA44 = &Parameters{Name: "ML-DSA-44", K: 4, L: 4, Eta: 2, Tau: 39, Beta: 78, Gamma1: 1 << 17, Gamma2: (Q - 1) / 88, Omega: 80, Lambda: 128}
+	MLDSA65 = &Parameters{Name: "ML-DSA-65", K: 6, L: 5, Eta: 4, Tau: 49, Beta: 196, Gamma1: 1 << 19, Gamma2: (Q - 1) / 32, Omega: 55, Lambda: 192}
+	MLDSA87 = &Parameters{Name: "ML-DSA-87", K: 8, L: 7, Eta: 2, Tau: 60, Beta: 120, Gamma1: 1 << 19, Gamma2: (Q - 1) / 32, Omega: 75, Lambda: 256}
+)
+
+func (params *Parameters) String() string {
+	return params.Name
+}
+
+func (params *Parameters) PublicKeySize() int {
+	return 32 + params.K*t1Size
+}
+
+func (params *Parameters) PrivateKeySize() int {
+	return 32 + 32 + 64 + (params.K+params.L)*params.etaSize() + params.K*t0Size
+}
+
+func (params *Parameters) SignatureSize() int {
+	return params.Lambda/4 + params.L*params.zSize() + params.Omega + params.K
+}
+
+func checkRing() error {
+	r := latticehelper.MainRing
+	if r == nil || r.N() != N || r.Level() != 0 || r.Modulus().Uint64() != Q {
+		return fmt.Errorf("%w, call latticehelper.InitSingle(%d, %d)", ErrRing, N, Q)
+	}
+	return nil
+}
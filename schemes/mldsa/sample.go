@@ -0,0 +1,135 @@
+package mldsa
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+	"golang.org/x/crypto/sha3"
+)
+
+// H of FIPS 204, SHAKE256 over the concatenation of parts
+func h(outLen int, parts ...[]byte) []byte {
+	shake := sha3.NewShake256()
+	for _, part := range parts {
+		shake.Write(part)
+	}
+	out := make([]byte, outLen)
+	shake.Read(out)
+	return out
+}
+
+// Algorithm 30, RejNTTPoly
+func rejNTTPoly(seed []byte) []int64 {
+	shake := sha3.NewShake128()
+	shake.Write(seed)
+
+	coeffs := make([]int64, 0, N)
+	var buf [3]byte
+	for len(coeffs) < N {
+		shake.Read(buf[:])
+		if z := int64(buf[0]) | int64(buf[1])<<8 | int64(buf[2]&0x7f)<<16; z < Q {
+			coeffs = append(coeffs, z)
+		}
+	}
+	return coeffs
+}
+
+// Algorithm 15, CoeffFromHalfByte
+func coeffFromHalfByte(b byte, eta int64) (int64, bool) {
+	switch {
+	case eta == 2 && b < 15:
+		return 2 - int64(b%5), true
+	case eta == 4 && b < 9:
+		return 4 - int64(b), true
+	}
+	return 0, false
+}
+
+// Algorithm 31, RejBoundedPoly
+func rejBoundedPoly(seed []byte, eta int64) poly.PolyQ {
+	shake := sha3.NewShake256()
+	shake.Write(seed)
+
+	coeffs := make([]int64, 0, N)
+	var z [1]byte
+	for len(coeffs) < N {
+		shake.Read(z[:])
+		if c, ok := coeffFromHalfByte(z[0]&0x0f, eta); ok {
+			coeffs = append(coeffs, c)
+		}
+		if c, ok := coeffFromHalfByte(z[0]>>4, eta); ok && len(coeffs) < N {
+			coeffs = append(coeffs, c)
+		}
+	}
+	return poly.NewPolyQFromCoeffs(coeffs...)
+}
+
+// Algorithm 32, ExpandA, returned in the coefficient representation
+func (params *Parameters) ExpandA(rho []byte) matrix.PolyQMatrix {
+	a := matrix.NewZeroPolyQMatrix(params.K, params.L)
+	seed := make([]byte, len(rho)+2)
+	copy(seed, rho)
+	for r := range a {
+		for s := range a[r] {
+			seed[len(rho)], seed[len(rho)+1] = byte(s), byte(r)
+			a[r][s] = fromNTT(rejNTTPoly(seed))
+		}
+	}
+	return a
+}
+
+// Algorithm 33, ExpandS
+func (params *Parameters) ExpandS(rho []byte) (s1, s2 vector.PolyQVector) {
+	seed := make([]byte, len(rho)+2)
+	copy(seed, rho)
+
+	s := make(vector.PolyQVector, params.L+params.K)
+	for r := range s {
+		binary.LittleEndian.PutUint16(seed[len(rho):], uint16(r))
+		s[r] = rejBoundedPoly(seed, params.Eta)
+	}
+	return s[:params.L], s[params.L:]
+}
+
+// Algorithm 34, ExpandMask
+func (params *Parameters) ExpandMask(rho []byte, kappa int) vector.PolyQVector {
+	c := 1 + bits.Len64(uint64(params.Gamma1-1))
+	seed := make([]byte, len(rho)+2)
+	copy(seed, rho)
+
+	y := make(vector.PolyQVector, params.L)
+	for r := range y {
+		binary.LittleEndian.PutUint16(seed[len(rho):], uint16(kappa+r))
+		// Every c-bit value is in range, so this cannot fail
+		y[r].UnpackBounded(h(32*c, seed), -(params.Gamma1 - 1), params.Gamma1)
+	}
+	return y
+}
+
+// Algorithm 29, SampleInBall: tau coefficients are +-1, the others zero
+func (params *Parameters) SampleInBall(cTilde []byte) poly.PolyQ {
+	shake := sha3.NewShake256()
+	shake.Write(cTilde)
+
+	var signs [8]byte
+	shake.Read(signs[:])
+	s := binary.LittleEndian.Uint64(signs[:])
+
+	c := make([]int64, N)
+	var j [1]byte
+	for i := N - params.Tau; i < N; i++ {
+		for {
+			shake.Read(j[:])
+			if int(j[0]) <= i {
+				break
+			}
+		}
+		c[i] = c[j[0]]
+		c[j[0]] = 1 - 2*int64(s&1)
+		s >>= 1
+	}
+	return poly.NewPolyQFromCoeffs(c...)
+}
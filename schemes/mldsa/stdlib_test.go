@@ -0,0 +1,60 @@
+//go:build go1.27
+
+// crypto/mldsa needs a newer language version than go.mod declares
+
+package mldsa
+
+import (
+	"bytes"
+	"crypto/mldsa"
+	"testing"
+)
+
+// Cross-checks against the FIPS 140-3 validated implementation of the
+// standard library: same keys from the same seed, same deterministic
+// signatures, and signatures accepted both ways
+func TestAgainstStandardLibrary(t *testing.T) {
+	stdParams := map[*Parameters]mldsa.Parameters{
+		MLDSA44: mldsa.MLDSA44(),
+		MLDSA65: mldsa.MLDSA65(),
+		MLDSA87: mldsa.MLDSA87(),
+	}
+
+	for params, std := range stdParams {
+		for i := 0; i < 3; i++ {
+			seed := bytes.Repeat([]byte{byte(i)}, SeedSize)
+			msg := bytes.Repeat([]byte("msg"), i*50)
+			ctx := bytes.Repeat([]byte{'c'}, i)
+
+			pk, sk, err := NewKeyFromSeed(params, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stdSK, err := mldsa.NewPrivateKey(std, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(pk.Bytes(), stdSK.PublicKey().Bytes()) {
+				t.Fatalf("%v: public keys differ for seed %d", params, i)
+			}
+
+			sig, _ := sk.SignDeterministic(msg, ctx)
+			stdSig, err := stdSK.SignDeterministic(msg, &mldsa.Options{Context: string(ctx)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(sig, stdSig) {
+				t.Errorf("%v: deterministic signatures differ for seed %d", params, i)
+			}
+
+			hedged, _ := sk.Sign(nil, msg, ctx)
+			if err := mldsa.Verify(stdSK.PublicKey(), msg, hedged, &mldsa.Options{Context: string(ctx)}); err != nil {
+				t.Errorf("%v: standard library rejects our signature: %v", params, err)
+			}
+			stdHedged, _ := stdSK.Sign(nil, msg, &mldsa.Options{Context: string(ctx)})
+			if !pk.Verify(msg, stdHedged, ctx) {
+				t.Errorf("%v: standard library signature rejected", params)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package mlkem
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper/kat"
+)
+
+// ACVP vectors of NIST for ML-KEM-512, the only parameter set crypto/mlkem
+// does not have. See the comments at the top of the files for the source.
+func TestACVPKeyGen(t *testing.T) {
+	f, err := kat.ParseFile("testdata/ML-KEM-512-keyGen.rsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Records) != 25 {
+		t.Fatalf("%d key generation vectors", len(f.Records))
+	}
+	for _, rec := range f.Records {
+		d, _ := rec.Bytes("d")
+		z, _ := rec.Bytes("z")
+		dk, err := NewKeyFromSeed(MLKEM512, append(d, z...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rec.Check("ek", dk.EncapsulationKey().Bytes()); err != nil {
+			t.Error(err)
+		}
+		if err := rec.Check("dk", dk.Bytes()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestACVPEncapDecap(t *testing.T) {
+	f, err := kat.ParseFile("testdata/ML-KEM-512-encapDecap.rsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encaps, decaps := 0, 0
+	for _, rec := range f.Records {
+		c, _ := rec.Bytes("c")
+
+		if _, ok := rec.Value("dk"); ok {
+			b, _ := rec.Bytes("dk")
+			dk, err := ParseDecapsulationKey(MLKEM512, b)
+			if err != nil {
+				t.Fatalf("count %d: %v", rec.Count, err)
+			}
+			k, err := dk.Decapsulate(c)
+			if err != nil {
+				t.Fatalf("count %d: %v", rec.Count, err)
+			}
+			if err := rec.Check("k", k); err != nil {
+				t.Error(err)
+			}
+			decaps++
+			continue
+		}
+
+		b, _ := rec.Bytes("ek")
+		m, _ := rec.Bytes("m")
+		ek, err := ParseEncapsulationKey(MLKEM512, b)
+		if err != nil {
+			t.Fatalf("count %d: %v", rec.Count, err)
+		}
+		k, ct, _ := ek.EncapsulateDeterministic(m)
+		if !bytes.Equal(ct, c) {
+			t.Errorf("count %d: ciphertexts differ", rec.Count)
+		}
+		if err := rec.Check("k", k); err != nil {
+			t.Error(err)
+		}
+		encaps++
+	}
+	if encaps != 25 || decaps != 10 {
+		t.Errorf("%d encapsulation and %d decapsulation vectors", encaps, decaps)
+	}
+}
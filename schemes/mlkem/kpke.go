@@ -0,0 +1,140 @@
+package mlkem
+
+import (
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// Compress_d of FIPS 203, round(2^d / q * x) mod 2^d
+func compress(p poly.Poly, d int) poly.Poly {
+	ret := poly.NewPoly()
+	for i, x := range reduce(p) {
+		ret[i] = ((x<<d + Q/2) / Q) & (1<<d - 1)
+	}
+	return ret
+}
+
+func compressVector(vec vector.PolyVector, d int) vector.PolyVector {
+	ret := make(vector.PolyVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = compress(p, d)
+	}
+	return ret
+}
+
+// Decompress_d of FIPS 203, round(q / 2^d * y)
+func decompress(p poly.Poly, d int) poly.Poly {
+	ret := poly.NewPoly()
+	for i, y := range p {
+		ret[i] = (y*Q + 1<<(d-1)) >> d
+	}
+	return ret
+}
+
+// ByteEncode_d of a vector, every entry packed in turn
+func byteEncode(vec vector.PolyVector, d int) []byte {
+	b := make([]byte, 0, vec.Length()*poly.PackedSize(d))
+	for _, p := range vec {
+		packed, err := p.PackBits(d)
+		if err != nil {
+			panic(err)
+		}
+		b = append(b, packed...)
+	}
+	return b
+}
+
+// ByteDecode_d of a vector. For d = 12 coefficients of q or more are
+// rejected, which is the modulus check of FIPS 203 for keys.
+func byteDecode(b []byte, d, length int) (vector.PolyVector, bool) {
+	size := poly.PackedSize(d)
+	if len(b) != length*size {
+		return nil, false
+	}
+
+	vec := make(vector.PolyVector, length)
+	for i := range vec {
+		if err := vec[i].UnpackBits(b[i*size:(i+1)*size], d); err != nil {
+			return nil, false
+		}
+		if d == 12 && vec[i].InfiniteNorm() >= Q {
+			return nil, false
+		}
+	}
+	return vec, true
+}
+
+func nttVector(vec vector.PolyVector) vector.PolyVector {
+	ret := make(vector.PolyVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = toNTT(p)
+	}
+	return ret
+}
+
+func fromNTTVector(vec vector.PolyVector) vector.PolyVector {
+	ret := make(vector.PolyVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = fromNTT(p)
+	}
+	return ret
+}
+
+// Algorithm 13, K-PKE.KeyGen. t and s are returned on coefficients.
+func (params *Parameters) pkeKeyGen(d []byte) (t vector.PolyVector, rho []byte, s vector.PolyVector) {
+	rho, sigma := g(d, []byte{byte(params.K)})
+
+	a := params.expandA(rho)
+	s = make(vector.PolyVector, params.K)
+	e := make(vector.PolyVector, params.K)
+	for i := range s {
+		s[i] = samplePolyCBD(prf(params.Eta1, sigma, byte(i)), params.Eta1)
+		e[i] = samplePolyCBD(prf(params.Eta1, sigma, byte(params.K+i)), params.Eta1)
+	}
+
+	t = reduceVector(a.VecMul(s).Add(e))
+	return t, rho, reduceVector(s)
+}
+
+// Algorithm 14, K-PKE.Encrypt
+func (params *Parameters) pkeEncrypt(t vector.PolyVector, rho, m, r []byte) []byte {
+	a := params.expandA(rho)
+
+	y := make(vector.PolyVector, params.K)
+	e1 := make(vector.PolyVector, params.K)
+	for i := range y {
+		y[i] = samplePolyCBD(prf(params.Eta1, r, byte(i)), params.Eta1)
+		e1[i] = samplePolyCBD(prf(params.Eta2, r, byte(params.K+i)), params.Eta2)
+	}
+	e2 := samplePolyCBD(prf(params.Eta2, r, byte(2*params.K)), params.Eta2)
+
+	var mu poly.Poly
+	mu.UnpackBits(m, 1)
+	mu = decompress(mu, 1)
+
+	// u = A^T y + e1, v = t^T y + e2 + mu
+	u := a.LeftVecMul(y).Add(e1)
+	v := t.DotProduct(y).Add(e2).Add(mu)
+
+	c1 := byteEncode(compressVector(u, params.Du), params.Du)
+	c2 := byteEncode(vector.PolyVector{compress(v, params.Dv)}, params.Dv)
+	return append(c1, c2...)
+}
+
+// Algorithm 15, K-PKE.Decrypt. The ciphertext has the right length.
+func (params *Parameters) pkeDecrypt(s vector.PolyVector, c []byte) []byte {
+	split := 32 * params.Du * params.K
+	u, _ := byteDecode(c[:split], params.Du, params.K)
+	v, _ := byteDecode(c[split:], params.Dv, 1)
+
+	for i := range u {
+		u[i] = decompress(u[i], params.Du)
+	}
+	w := decompress(v[0], params.Dv).Sub(s.DotProduct(u))
+
+	m, err := compress(w, 1).PackBits(1)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -0,0 +1,164 @@
+package mlkem
+
+import (
+	"crypto/subtle"
+	"io"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// T is t = A*s + e on coefficients in [0, q), encoded in the NTT domain
+type EncapsulationKey struct {
+	Params *Parameters
+	Rho    []byte
+	T      vector.PolyVector
+}
+
+type DecapsulationKey struct {
+	Params *Parameters
+	S      vector.PolyVector
+	Ek     *EncapsulationKey
+	// H(ek), 32 bytes
+	H []byte
+	// Implicit rejection seed, 32 bytes
+	Z []byte
+}
+
+// Algorithm 19 with randomness from rand, crypto/rand if nil
+func GenerateKey(params *Parameters, rand io.Reader) (*DecapsulationKey, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(latticehelper.RandReader(rand), seed); err != nil {
+		return nil, err
+	}
+	return NewKeyFromSeed(params, seed)
+}
+
+// Algorithm 16, ML-KEM.KeyGen_internal(d, z) with seed = d || z, the same
+// seed format as crypto/mlkem
+func NewKeyFromSeed(params *Parameters, seed []byte) (*DecapsulationKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	if len(seed) != SeedSize {
+		return nil, ErrKey
+	}
+
+	t, rho, s := params.pkeKeyGen(seed[:32])
+	ek := &EncapsulationKey{Params: params, Rho: rho, T: t}
+	z := append([]byte(nil), seed[32:]...)
+	return &DecapsulationKey{Params: params, S: s, Ek: ek, H: hash(ek.Bytes()), Z: z}, nil
+}
+
+func (dk *DecapsulationKey) EncapsulationKey() *EncapsulationKey {
+	return dk.Ek
+}
+
+// ByteEncode_12(NTT(t)) || rho
+func (ek *EncapsulationKey) Bytes() []byte {
+	return append(byteEncode(nttVector(ek.T), 12), ek.Rho...)
+}
+
+// ByteEncode_12(NTT(s)) || ek || H(ek) || z
+func (dk *DecapsulationKey) Bytes() []byte {
+	b := make([]byte, 0, dk.Params.DecapsulationKeySize())
+	b = append(b, byteEncode(nttVector(dk.S), 12)...)
+	b = append(b, dk.Ek.Bytes()...)
+	b = append(b, dk.H...)
+	return append(b, dk.Z...)
+}
+
+// Includes the modulus check of FIPS 203, section 7.2
+func ParseEncapsulationKey(params *Parameters, b []byte) (*EncapsulationKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	if len(b) != params.EncapsulationKeySize() {
+		return nil, ErrKey
+	}
+
+	split := 384 * params.K
+	tHat, ok := byteDecode(b[:split], 12, params.K)
+	if !ok {
+		return nil, ErrKey
+	}
+	rho := append([]byte(nil), b[split:]...)
+	return &EncapsulationKey{Params: params, Rho: rho, T: fromNTTVector(tHat)}, nil
+}
+
+// Includes the hash check of FIPS 203, section 7.3
+func ParseDecapsulationKey(params *Parameters, b []byte) (*DecapsulationKey, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	if len(b) != params.DecapsulationKeySize() {
+		return nil, ErrKey
+	}
+
+	k := params.K
+	sHat, ok := byteDecode(b[:384*k], 12, k)
+	if !ok {
+		return nil, ErrKey
+	}
+	ekBytes := b[384*k : 768*k+32]
+	ek, err := ParseEncapsulationKey(params, ekBytes)
+	if err != nil {
+		return nil, err
+	}
+	h := b[768*k+32 : 768*k+64]
+	if subtle.ConstantTimeCompare(h, hash(ekBytes)) != 1 {
+		return nil, ErrKey
+	}
+
+	return &DecapsulationKey{
+		Params: params,
+		S:      fromNTTVector(sHat),
+		Ek:     ek,
+		H:      append([]byte(nil), h...),
+		Z:      append([]byte(nil), b[768*k+64:]...),
+	}, nil
+}
+
+// Algorithm 20 with 32 bytes from rand, crypto/rand if nil
+func (ek *EncapsulationKey) Encapsulate(rand io.Reader) (sharedKey, ciphertext []byte, err error) {
+	m := make([]byte, 32)
+	if _, err := io.ReadFull(latticehelper.RandReader(rand), m); err != nil {
+		return nil, nil, err
+	}
+	return ek.EncapsulateDeterministic(m)
+}
+
+// Algorithm 17, ML-KEM.Encaps_internal(ek, m), for known-answer tests
+func (ek *EncapsulationKey) EncapsulateDeterministic(m []byte) (sharedKey, ciphertext []byte, err error) {
+	if err := checkRing(); err != nil {
+		return nil, nil, err
+	}
+	if len(m) != 32 {
+		return nil, nil, ErrKey
+	}
+
+	sharedKey, r := g(m, hash(ek.Bytes()))
+	return sharedKey, ek.Params.pkeEncrypt(ek.T, ek.Rho, m, r), nil
+}
+
+// Algorithms 18 and 21. A well-formed but wrong ciphertext gives the
+// pseudorandom key J(z || c) instead of an error (implicit rejection).
+func (dk *DecapsulationKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	if err := checkRing(); err != nil {
+		return nil, err
+	}
+	params := dk.Params
+	if len(ciphertext) != params.CiphertextSize() {
+		return nil, ErrCiphertext
+	}
+
+	mPrime := params.pkeDecrypt(dk.S, ciphertext)
+	kPrime, rPrime := g(mPrime, dk.H)
+	kBar := j(dk.Z, ciphertext)
+
+	cPrime := params.pkeEncrypt(dk.Ek.T, dk.Ek.Rho, mPrime, rPrime)
+	if subtle.ConstantTimeCompare(ciphertext, cPrime) != 1 {
+		return kBar, nil
+	}
+	return kPrime, nil
+}
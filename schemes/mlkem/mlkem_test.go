@@ -0,0 +1,194 @@
+package mlkem
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+)
+
+var allParams = []*Parameters{MLKEM512, MLKEM768, MLKEM1024}
+
+// Any degree 256 ring, the modulus is not used
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(N, 8380417)
+	m.Run()
+}
+
+func TestNTTRoundTrip(t *testing.T) {
+	p := poly.NewPoly()
+	for i := range p {
+		p[i] = int64(i*i) % Q
+	}
+	if !fromNTT(toNTT(p)).Equals(p) {
+		t.Error("fromNTT does not invert toNTT")
+	}
+
+	// NTT of X is (zeta_i, -zeta_i) pairs of degree one polynomials
+	x := toNTT(poly.NewPolyFromCoeffs(0, 1))
+	for i := 0; i < N; i += 2 {
+		if x[i] != 0 || x[i+1] != 1 {
+			t.Fatalf("NTT of X is not X in every factor, got %d, %d at %d", x[i], x[i+1], i)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	p := poly.NewPoly()
+	for i := range p {
+		p[i] = int64(i) * 13
+	}
+	for _, d := range []int{1, 4, 5, 10, 11} {
+		back := decompress(compress(p, d), d)
+		bound := int64((Q + 1<<d) >> (d + 1))
+		for i := range p {
+			diff := latticehelper.PositiveMod(back[i]-p[i], Q)
+			if diff > Q/2 {
+				diff = Q - diff
+			}
+			if diff > bound {
+				t.Fatalf("d = %d: %d decompressed to %d", d, p[i], back[i])
+			}
+		}
+	}
+}
+
+// SHA-256 of the encapsulation key, decapsulation key, ciphertext and shared
+// key for the all-zero seed and message. ML-KEM-768 and ML-KEM-1024 agree
+// with crypto/mlkem, see stdlib_test.go, ML-KEM-512 passes the ACVP vectors
+// in acvp_test.go.
+func TestKnownAnswers(t *testing.T) {
+	expected := map[*Parameters][4]string{
+		MLKEM512: {
+			"52b46f0597ac5cb10c6281ad5731f18d599feaa92ce24d897d4084195b27e448",
+			"3a19948fd8e0d7af1e2f3bb32bf2299b91f40c66b3faeb773b8fc3dc2f140092",
+			"b9f7694fa5a2be9fb849d0c0ea8f55fce6d91eaecb9c34dffe47b5b5d6034de3",
+			"e9a21d9e6c451ac6b7b78b57c7fef1aeb43af246bc782efbacdca0e19bac2c62",
+		},
+		MLKEM768: {
+			"f95c185fe5b2335d2fc938dd889c6425944acd74376b6952bf1130f720f6ba99",
+			"a5e078867af0c0a9702149b3af1adf208dccf878bc9f9e32d4fb028473addd09",
+			"1f92454af68a16b43b8013a909a1df7058c44f170b5cf9cc00cb165a3ca58c68",
+			"890017970b0c8e2ef735cc912d90d68eaee0a3d88870db351f2e759795ac6275",
+		},
+		MLKEM1024: {
+			"29e3692e1c08422f548ca7e683e89015482c09a5442f8d2ead471c3931a5ee76",
+			"84798b42843b9f7dad54e19641ad787f61fa737cba34c2dd39414086d31a99c5",
+			"542bc0ef96b3120b2561c2f10735d1ab57f639b4a7292c5b7dcfe2111278fab7",
+			"57bf213985d271af456df8f38c36f84c4841d287da0a7611842be9a576dda235",
+		},
+	}
+
+	for params, hashes := range expected {
+		dk, err := NewKeyFromSeed(params, make([]byte, SeedSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, ct, _ := dk.EncapsulationKey().EncapsulateDeterministic(make([]byte, 32))
+
+		for i, b := range [][]byte{dk.EncapsulationKey().Bytes(), dk.Bytes(), ct, key} {
+			if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != hashes[i] {
+				t.Errorf("%v: known answer %d differs", params, i)
+			}
+		}
+	}
+}
+
+func TestSizes(t *testing.T) {
+	expected := map[string][3]int{
+		"ML-KEM-512":  {800, 1632, 768},
+		"ML-KEM-768":  {1184, 2400, 1088},
+		"ML-KEM-1024": {1568, 3168, 1568},
+	}
+	for _, params := range allParams {
+		dk, err := GenerateKey(params, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, ct, err := dk.EncapsulationKey().Encapsulate(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sizes := [3]int{len(dk.EncapsulationKey().Bytes()), len(dk.Bytes()), len(ct)}
+		if sizes != expected[params.Name] || sizes != [3]int{params.EncapsulationKeySize(), params.DecapsulationKeySize(), params.CiphertextSize()} {
+			t.Errorf("%v: sizes %v", params, sizes)
+		}
+		if len(key) != SharedKeySize {
+			t.Errorf("%v: shared key of %d bytes", params, len(key))
+		}
+	}
+}
+
+func TestEncapsulateDecapsulate(t *testing.T) {
+	for _, params := range allParams {
+		dk, _ := NewKeyFromSeed(params, bytes.Repeat([]byte{7}, SeedSize))
+
+		key, ct, err := dk.EncapsulationKey().Encapsulate(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := dk.Decapsulate(ct)
+		if err != nil || !bytes.Equal(got, key) {
+			t.Fatalf("%v: shared keys differ", params)
+		}
+
+		// Implicit rejection: J(z || c) instead of an error
+		ct[len(ct)/2] ^= 1
+		got, err = dk.Decapsulate(ct)
+		if err != nil || bytes.Equal(got, key) || !bytes.Equal(got, j(dk.Z, ct)) {
+			t.Errorf("%v: tampered ciphertext not rejected implicitly", params)
+		}
+
+		// Keys survive encoding
+		ek2, err := ParseEncapsulationKey(params, dk.EncapsulationKey().Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		dk2, err := ParseDecapsulationKey(params, dk.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := bytes.Repeat([]byte{1}, 32)
+		key1, ct1, _ := dk.EncapsulationKey().EncapsulateDeterministic(m)
+		key2, ct2, _ := ek2.EncapsulateDeterministic(m)
+		got, _ = dk2.Decapsulate(ct2)
+		if !bytes.Equal(ct1, ct2) || !bytes.Equal(key1, key2) || !bytes.Equal(got, key1) {
+			t.Errorf("%v: decoded keys behave differently", params)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	dk, _ := GenerateKey(MLKEM512, nil)
+	if _, err := ParseEncapsulationKey(MLKEM768, dk.EncapsulationKey().Bytes()); !errors.Is(err, ErrKey) {
+		t.Errorf("encapsulation key of the wrong size: %v", err)
+	}
+	if _, err := dk.Decapsulate(make([]byte, MLKEM768.CiphertextSize())); !errors.Is(err, ErrCiphertext) {
+		t.Errorf("ciphertext of the wrong size: %v", err)
+	}
+
+	// Modulus check, the first coefficient becomes 4095
+	ek := dk.EncapsulationKey().Bytes()
+	ek[0], ek[1] = 0xff, ek[1]|0x0f
+	if _, err := ParseEncapsulationKey(MLKEM512, ek); !errors.Is(err, ErrKey) {
+		t.Errorf("coefficient above q: %v", err)
+	}
+
+	// Hash check
+	b := dk.Bytes()
+	b[len(b)-33] ^= 1
+	if _, err := ParseDecapsulationKey(MLKEM512, b); !errors.Is(err, ErrKey) {
+		t.Errorf("wrong H(ek): %v", err)
+	}
+
+	latticehelper.InitSingle(128, 12289)
+	defer latticehelper.InitSingle(N, 8380417)
+	if _, err := GenerateKey(MLKEM512, nil); !errors.Is(err, ErrRing) {
+		t.Errorf("wrong ring: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package mlkem
+
+import (
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper"
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/vector"
+)
+
+// zetas[i] = 17^BitRev7(i) mod q, the twiddle factors of the FIPS 203 NTT
+var zetas = func() [128]int64 {
+	var ret [128]int64
+	for i := range ret {
+		ret[i] = 1
+		for e := bits.Reverse8(uint8(i)) >> 1; e > 0; e-- {
+			ret[i] = ret[i] * 17 % Q
+		}
+	}
+	return ret
+}()
+
+// Keys are encoded in the NTT representation of FIPS 203 (Algorithm 9),
+// arithmetic happens on coefficients. Both functions convert at the edges.
+func toNTT(p poly.Poly) poly.Poly {
+	f := reduce(p)
+
+	i := 1
+	for length := 128; length >= 2; length /= 2 {
+		for start := 0; start < N; start += 2 * length {
+			zeta := zetas[i]
+			i++
+			for j := start; j < start+length; j++ {
+				t := zeta * f[j+length] % Q
+				f[j+length] = (f[j] - t + Q) % Q
+				f[j] = (f[j] + t) % Q
+			}
+		}
+	}
+	return f
+}
+
+// Algorithm 10
+func fromNTT(fHat poly.Poly) poly.Poly {
+	f := reduce(fHat)
+
+	i := 127
+	for length := 2; length <= 128; length *= 2 {
+		for start := 0; start < N; start += 2 * length {
+			zeta := zetas[i]
+			i--
+			for j := start; j < start+length; j++ {
+				t := f[j]
+				f[j] = (t + f[j+length]) % Q
+				f[j+length] = zeta * (f[j+length] - t + Q) % Q
+			}
+		}
+	}
+
+	// 128^-1 mod q
+	for j := range f {
+		f[j] = f[j] * 3303 % Q
+	}
+	return f
+}
+
+// Coefficients in [0, q), as a new polynomial
+func reduce(p poly.Poly) poly.Poly {
+	ret := poly.NewPoly()
+	for i, coeff := range p {
+		ret[i] = latticehelper.PositiveMod(coeff, Q)
+	}
+	return ret
+}
+
+func reduceVector(vec vector.PolyVector) vector.PolyVector {
+	ret := make(vector.PolyVector, vec.Length())
+	for i, p := range vec {
+		ret[i] = reduce(p)
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+// Package mlkem implements ML-KEM (FIPS 203) on the ring types of
+// latticehelper.
+//
+// lattigo cannot build Z_3329[X]/(X^256 + 1): 3329 is not 1 mod 512, so the
+// ring has no full negacyclic NTT and PolyQ is not available for q = 3329.
+// The scheme therefore works on Poly, PolyVector and PolyMatrix over
+// Z[X]/(X^256 + 1) and reduces mod q itself. latticehelper.MainRing only
+// needs degree 256, its modulus is not used; the ML-DSA ring
+// latticehelper.InitSingle(256, 8380417) works.
+package mlkem
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+const (
+	N = 256
+	Q = 3329
+
+	// d || z of ML-KEM.KeyGen_internal
+	SeedSize      = 64
+	SharedKeySize = 32
+)
+
+var (
+	ErrRing       = errors.New("mlkem: latticehelper.MainRing does not have degree 256")
+	ErrKey        = errors.New("mlkem: malformed key")
+	ErrCiphertext = errors.New("mlkem: malformed ciphertext")
+)
+
+type Parameters struct {
+	Name       string
+	K          int
+	Eta1, Eta2 int
+	Du, Dv     int
+}
+
+var (
+	MLKEM512  = &Parameters{Name: "ML-KEM-512", K: 2, Eta1: 3, Eta2: 2, Du: 10, Dv: 4}
+	MLKEM768  = &Parameters{Name: "ML-KEM-768", K: 3, Eta1: 2, Eta2: 2, Du: 10, Dv: 4}
+	MLKEM1024 = &Parameters{Name: "ML-KEM-1024", K: 4, Eta1: 2, Eta2: 2, Du: 11, Dv: 5}
+)
+
+func (params *Parameters) String() string {
+	return params.Name
+}
+
+func (params *Parameters) EncapsulationKeySize() int {
+	return 384*params.K + 32
+}
+
+func (params *Parameters) DecapsulationKeySize() int {
+	return 768*params.K + 96
+}
+
+func (params *Parameters) CiphertextSize() int {
+	return 32 * (params.Du*params.K + params.Dv)
+}
+
+func checkRing() error {
+	if r := latticehelper.MainRing; r == nil || r.N() != N {
+		return fmt.Errorf("%w, initialize it with latticehelper.InitSingle(256, q) for an NTT-friendly q", ErrRing)
+	}
+	return nil
+}
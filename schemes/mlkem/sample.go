@@ -0,0 +1,96 @@
+package mlkem
+
+import (
+	"github.com/isri-pqc/latticehelper/poly"
+	"github.com/isri-pqc/latticehelper/poly/matrix"
+	"golang.org/x/crypto/sha3"
+)
+
+// G of FIPS 203, SHA3-512 split into two 32-byte halves
+func g(parts ...[]byte) ([]byte, []byte) {
+	h := sha3.New512()
+	for _, part := range parts {
+		h.Write(part)
+	}
+	out := h.Sum(nil)
+	return out[:32], out[32:]
+}
+
+// H of FIPS 203, SHA3-256
+func hash(b []byte) []byte {
+	out := sha3.Sum256(b)
+	return out[:]
+}
+
+// J of FIPS 203, 32 bytes of SHAKE256
+func j(parts ...[]byte) []byte {
+	shake := sha3.NewShake256()
+	for _, part := range parts {
+		shake.Write(part)
+	}
+	out := make([]byte, 32)
+	shake.Read(out)
+	return out
+}
+
+// PRF_eta of FIPS 203, 64 * eta bytes of SHAKE256(s || b)
+func prf(eta int, s []byte, b byte) []byte {
+	shake := sha3.NewShake256()
+	shake.Write(s)
+	shake.Write([]byte{b})
+	out := make([]byte, 64*eta)
+	shake.Read(out)
+	return out
+}
+
+// Algorithm 7, SampleNTT
+func sampleNTT(seed []byte) poly.Poly {
+	shake := sha3.NewShake128()
+	shake.Write(seed)
+
+	a := poly.NewPoly()
+	var c [3]byte
+	for j := 0; j < N; {
+		shake.Read(c[:])
+		d1 := int64(c[0]) | int64(c[1]&0x0f)<<8
+		d2 := int64(c[1]>>4) | int64(c[2])<<4
+		if d1 < Q {
+			a[j] = d1
+			j++
+		}
+		if d2 < Q && j < N {
+			a[j] = d2
+			j++
+		}
+	}
+	return a
+}
+
+// Algorithm 8, SamplePolyCBD_eta, coefficients in [-eta, eta]
+func samplePolyCBD(b []byte, eta int) poly.Poly {
+	bit := func(k int) int64 {
+		return int64(b[k/8] >> (k % 8) & 1)
+	}
+
+	f := poly.NewPoly()
+	for i := range f {
+		for j := 0; j < eta; j++ {
+			f[i] += bit(2*i*eta+j) - bit(2*i*eta+eta+j)
+		}
+	}
+	return f
+}
+
+// A[i][j] = SampleNTT(rho || j || i), converted to coefficients
+func (params *Parameters) expandA(rho []byte) matrix.PolyMatrix {
+	a := matrix.NewZeroPolyMatrix(params.K, params.K)
+	seed := make([]byte, 34)
+	copy(seed, rho)
+	for i := range a {
+		for j := range a[i] {
+			seed[32], seed[33] = byte(j), byte(i)
+			a[i][j] = fromNTT(sampleNTT(seed))
+		}
+	}
+	return a
+}
@@ -0,0 +1,77 @@
+//go:build go1.26
+
+// crypto/mlkem/mlkemtest needs a newer language version than go.mod declares
+
+package mlkem
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"crypto/mlkem/mlkemtest"
+	"testing"
+)
+
+// Cross-checks against the FIPS 140-3 validated implementation of the
+// standard library, which has no ML-KEM-512: same keys from the same seed,
+// same deterministic encapsulations, and ciphertexts decapsulated both ways
+func TestAgainstStandardLibrary(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		seed := bytes.Repeat([]byte{byte(i)}, SeedSize)
+		m := bytes.Repeat([]byte{byte(i + 100)}, 32)
+
+		for _, params := range []*Parameters{MLKEM768, MLKEM1024} {
+			dk, err := NewKeyFromSeed(params, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, ct, _ := dk.EncapsulationKey().EncapsulateDeterministic(m)
+
+			var stdEK, stdKey, stdCT []byte
+			var stdDecaps func([]byte) ([]byte, error)
+			if params == MLKEM768 {
+				stdDK, err := mlkem.NewDecapsulationKey768(seed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stdEK = stdDK.EncapsulationKey().Bytes()
+				stdKey, stdCT, err = mlkemtest.Encapsulate768(stdDK.EncapsulationKey(), m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stdDecaps = stdDK.Decapsulate
+			} else {
+				stdDK, err := mlkem.NewDecapsulationKey1024(seed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stdEK = stdDK.EncapsulationKey().Bytes()
+				stdKey, stdCT, err = mlkemtest.Encapsulate1024(stdDK.EncapsulationKey(), m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stdDecaps = stdDK.Decapsulate
+			}
+
+			if !bytes.Equal(dk.EncapsulationKey().Bytes(), stdEK) {
+				t.Fatalf("%v: encapsulation keys differ for seed %d", params, i)
+			}
+			if !bytes.Equal(key, stdKey) || !bytes.Equal(ct, stdCT) {
+				t.Errorf("%v: encapsulations differ for seed %d", params, i)
+			}
+
+			ours, _ := dk.Decapsulate(stdCT)
+			theirs, err := stdDecaps(ct)
+			if err != nil || !bytes.Equal(ours, stdKey) || !bytes.Equal(theirs, key) {
+				t.Errorf("%v: decapsulation across implementations failed for seed %d", params, i)
+			}
+
+			// Implicit rejection agrees too
+			ct[0] ^= 1
+			ours, _ = dk.Decapsulate(ct)
+			theirs, _ = stdDecaps(ct)
+			if !bytes.Equal(ours, theirs) {
+				t.Errorf("%v: implicit rejection keys differ for seed %d", params, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+// JSON form of the polynomial types. By default a polynomial is a plain
+// array of its N coefficients, a vector an array of those and a matrix an
+// array of rows.
+type JSONOptions struct {
+	// PolyQ coefficients in (-q/2, q/2] instead of [0, q)
+	Centered bool
+	// Wrap the coefficients in an object with the type and ring parameters,
+	// {"type": ..., "n": ..., "moduli": [...], "centered": ..., "coeffs": ...}
+	Metadata bool
+}
+
+type jsonDocument struct {
+	Type     string          `json:"type"`
+	N        int             `json:"n"`
+	Moduli   []uint64        `json:"moduli"`
+	Centered bool            `json:"centered"`
+	Coeffs   json.RawMessage `json:"coeffs"`
+}
+
+func ringModuli() []uint64 {
+	r := latticehelper.MainRing
+	return slices.Clone(r.ModuliChain()[:r.Level()+1])
+}
+
+// coeffs is the []int64, [][]int64 or [][][]int64 coefficient array
+func MarshalJSON(tag Tag, coeffs any, opts JSONOptions) ([]byte, error) {
+	raw, err := json.Marshal(coeffs)
+	if err != nil || !opts.Metadata {
+		return raw, err
+	}
+
+	return json.Marshal(jsonDocument{
+		Type:     tag.String(),
+		N:        latticehelper.MainRing.N(),
+		Moduli:   ringModuli(),
+		Centered: opts.Centered,
+		Coeffs:   raw,
+	})
+}
+
+// Returns the coefficient array of data, checking type and ring
+// parameters if data is a metadata object
+func UnwrapJSON(data []byte, tag Tag) (json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return data, nil
+	}
+
+	var doc jsonDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+
+	if doc.Type != tag.String() {
+		return nil, fmt.Errorf("%w: got %q, want %q", ErrType, doc.Type, tag.String())
+	}
+	if doc.N != latticehelper.MainRing.N() || !slices.Equal(doc.Moduli, ringModuli()) {
+		return nil, fmt.Errorf("%w: n = %d, moduli %v", ErrRing, doc.N, doc.Moduli)
+	}
+	if doc.Coeffs == nil {
+		return nil, fmt.Errorf("%w: missing coeffs", ErrMalformed)
+	}
+	return doc.Coeffs, nil
+}
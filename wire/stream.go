@@ -0,0 +1,217 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// Set in the type tag byte when every row of the stream is followed by a
+// CRC-32C (Castagnoli, little endian) of the row's polynomials
+const FlagRowChecksums Tag = 0x80
+
+var ErrChecksum = errors.New("wire: row checksum mismatch")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+type StreamOptions struct {
+	RowChecksums bool
+}
+
+// Writes a matrix one polynomial at a time. Without checksums the bytes are
+// the same as the ones of MarshalBinary. The first error is kept and every
+// later call does nothing.
+type Writer struct {
+	w    io.Writer
+	n    int64
+	err  error
+	crc  hash.Hash32
+	buf  []byte
+	cols int
+	left int
+}
+
+// Writes the header for a rows x cols matrix of type tag
+func NewWriter(w io.Writer, tag Tag, rows, cols int, opts StreamOptions) *Writer {
+	sw := &Writer{w: w, cols: cols, left: cols}
+	if rows < 0 || cols < 0 || uint64(rows) > math.MaxUint32 || uint64(cols) > math.MaxUint32 {
+		sw.err = fmt.Errorf("wire: dimensions %dx%d do not fit 32 bits", rows, cols)
+		return sw
+	}
+	if (rows == 0) != (cols == 0) {
+		sw.err = fmt.Errorf("wire: dimensions %dx%d cannot be streamed, NewReader rejects them", rows, cols)
+		return sw
+	}
+
+	if opts.RowChecksums {
+		tag |= FlagRowChecksums
+		sw.crc = crc32.New(castagnoli)
+	}
+	sw.write(AppendHeader(nil, tag, rows, cols), false)
+	return sw
+}
+
+func (sw *Writer) write(b []byte, checksummed bool) {
+	if sw.err != nil {
+		return
+	}
+	n, err := sw.w.Write(b)
+	sw.n += int64(n)
+	sw.err = err
+	if checksummed && sw.crc != nil {
+		sw.crc.Write(b)
+	}
+}
+
+// Ends the row after cols polynomials
+func (sw *Writer) endPoly() {
+	sw.left--
+	if sw.left > 0 {
+		return
+	}
+	sw.left = sw.cols
+	if sw.crc != nil {
+		sw.write(binary.LittleEndian.AppendUint32(sw.buf[:0], sw.crc.Sum32()), false)
+		sw.crc.Reset()
+	}
+}
+
+func (sw *Writer) WritePoly(coeffs []int64) {
+	sw.buf = AppendPoly(sw.buf[:0], coeffs)
+	sw.write(sw.buf, true)
+	sw.endPoly()
+}
+
+// Stops at the first error, so a malformed polynomial is never encoded
+func (sw *Writer) WritePolyQ(coeffs [][]uint64) {
+	if sw.err != nil {
+		return
+	}
+	if sw.err = CheckPolyQ(coeffs); sw.err != nil {
+		return
+	}
+	sw.buf = AppendPolyQ(sw.buf[:0], coeffs)
+	sw.write(sw.buf, true)
+	sw.endPoly()
+}
+
+// Bytes written so far and the first error
+func (sw *Writer) Result() (int64, error) {
+	return sw.n, sw.err
+}
+
+// Reads a matrix written by Writer or MarshalBinary one polynomial at a
+// time, never reading past its end
+type Reader struct {
+	r          io.Reader
+	n          int64
+	crc        hash.Hash32
+	buf        []byte
+	rows, cols int
+	left       int
+}
+
+// Reads and checks the header against tag and the current ring
+func NewReader(r io.Reader, tag Tag) (*Reader, error) {
+	sr := &Reader{r: r}
+
+	header := make([]byte, HeaderSize)
+	if err := sr.read(header); err != nil {
+		return sr, err
+	}
+
+	if Tag(header[5])&FlagRowChecksums != 0 {
+		header[5] &^= byte(FlagRowChecksums)
+		sr.crc = crc32.New(castagnoli)
+	}
+
+	rows, cols, _, err := ParseHeader(header, tag)
+	if err != nil {
+		return sr, err
+	}
+	// rows x 0 reads no data, any number of empty rows would be accepted
+	if (rows == 0) != (cols == 0) {
+		return sr, fmt.Errorf("%w: %v with dimensions %dx%d", ErrMalformed, tag, rows, cols)
+	}
+
+	sr.rows, sr.cols, sr.left = rows, cols, cols
+	return sr, nil
+}
+
+func (sr *Reader) read(b []byte) error {
+	n, err := io.ReadFull(sr.r, b)
+	sr.n += int64(n)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: truncated stream", ErrMalformed)
+	}
+	return err
+}
+
+func (sr *Reader) Dims() (rows, cols int) {
+	return sr.rows, sr.cols
+}
+
+func (sr *Reader) readPoly(size int) error {
+	if cap(sr.buf) < size {
+		sr.buf = make([]byte, size)
+	}
+	sr.buf = sr.buf[:size]
+	if err := sr.read(sr.buf); err != nil {
+		return err
+	}
+	if sr.crc != nil {
+		sr.crc.Write(sr.buf)
+	}
+	return nil
+}
+
+// Checks the row checksum after cols polynomials
+func (sr *Reader) endPoly() error {
+	sr.left--
+	if sr.left > 0 {
+		return nil
+	}
+	sr.left = sr.cols
+	if sr.crc == nil {
+		return nil
+	}
+
+	var sum [4]byte
+	if err := sr.read(sum[:]); err != nil {
+		return err
+	}
+	if binary.LittleEndian.Uint32(sum[:]) != sr.crc.Sum32() {
+		return ErrChecksum
+	}
+	sr.crc.Reset()
+	return nil
+}
+
+func (sr *Reader) ReadPoly(coeffs []int64) error {
+	if err := sr.readPoly(8 * len(coeffs)); err != nil {
+		return err
+	}
+	if _, err := ReadPoly(sr.buf, coeffs); err != nil {
+		return err
+	}
+	return sr.endPoly()
+}
+
+func (sr *Reader) ReadPolyQ(coeffs [][]uint64) error {
+	if err := sr.readPoly(PolyQSize()); err != nil {
+		return err
+	}
+	if _, err := ReadPolyQ(sr.buf, coeffs); err != nil {
+		return err
+	}
+	return sr.endPoly()
+}
+
+// Bytes read so far
+func (sr *Reader) Count() int64 {
+	return sr.n
+}
@@ -0,0 +1,68 @@
+package wire
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestStreamRowChecksums(t *testing.T) {
+	coeffs := []int64{1, -2, 3}
+	polySize := 8 * len(coeffs)
+
+	var buf bytes.Buffer
+	sw := NewWriter(&buf, TagPolyMatrix, 2, 2, StreamOptions{RowChecksums: true})
+	for i := 0; i < 4; i++ {
+		sw.WritePoly(coeffs)
+	}
+	n, err := sw.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(HeaderSize+4*polySize+2*4) || n != int64(buf.Len()) {
+		t.Fatalf("wrote %d bytes, buffer holds %d", n, buf.Len())
+	}
+
+	read := func(data []byte) error {
+		sr, err := NewReader(bytes.NewReader(data), TagPolyMatrix)
+		if err != nil {
+			return err
+		}
+		decoded := make([]int64, len(coeffs))
+		for i := 0; i < 4; i++ {
+			if err := sr.ReadPoly(decoded); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := read(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Flip a bit of the first polynomial of the second row
+	corrupt := append([]byte(nil), buf.Bytes()...)
+	corrupt[HeaderSize+2*polySize+4] ^= 1
+	if err := read(corrupt); !errors.Is(err, ErrChecksum) {
+		t.Errorf("corrupted row accepted: %v", err)
+	}
+	if err := read(buf.Bytes()[:buf.Len()-1]); !errors.Is(err, ErrMalformed) {
+		t.Errorf("truncated stream accepted: %v", err)
+	}
+}
+
+func TestStreamWriterErrors(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, TagPolyMatrix, 1<<32, 1, StreamOptions{}).Result(); err == nil {
+		t.Error("dimensions above 32 bits accepted")
+	}
+	if _, err := NewWriter(failingWriter{}, TagPolyMatrix, 1, 1, StreamOptions{}).Result(); err == nil {
+		t.Error("write error not reported")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
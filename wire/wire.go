@@ -0,0 +1,215 @@
+// Package wire is the versioned binary format behind the MarshalBinary and
+// UnmarshalBinary methods of the polynomial, vector and matrix types.
+//
+// Every encoding starts with a fixed header
+//
+//	magic "LTHP" | version (1 byte) | type tag (1 byte) |
+//	ring fingerprint (8 bytes) | rows (uint32) | cols (uint32)
+//
+// followed by rows*cols polynomials. Polynomials use dimensions 1x1 and
+// vectors 1xlength. All integers are little endian. A Poly coefficient is
+// an int64, a PolyQ coefficient takes the fewest bytes that fit its
+// modulus, for every modulus of the ring in turn.
+package wire
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+const Version = 1
+
+const HeaderSize = 4 + 1 + 1 + 8 + 4 + 4
+
+var magic = [4]byte{'L', 'T', 'H', 'P'}
+
+var (
+	ErrMagic     = errors.New("wire: not a latticehelper encoding")
+	ErrVersion   = errors.New("wire: unsupported format version")
+	ErrType      = errors.New("wire: unexpected type tag")
+	ErrRing      = errors.New("wire: ring parameters do not match")
+	ErrMalformed = errors.New("wire: malformed data")
+)
+
+type Tag uint8
+
+const (
+	TagPoly Tag = iota + 1
+	TagPolyQ
+	TagPolyVector
+	TagPolyQVector
+	TagPolyMatrix
+	TagPolyQMatrix
+)
+
+func (tag Tag) String() string {
+	switch tag {
+	case TagPoly:
+		return "Poly"
+	case TagPolyQ:
+		return "PolyQ"
+	case TagPolyVector:
+		return "PolyVector"
+	case TagPolyQVector:
+		return "PolyQVector"
+	case TagPolyMatrix:
+		return "PolyMatrix"
+	case TagPolyQMatrix:
+		return "PolyQMatrix"
+	}
+	return fmt.Sprintf("Tag(%d)", uint8(tag))
+}
+
+// First 8 bytes of SHA-256 over the degree and moduli of latticehelper.MainRing
+func RingFingerprint() [8]byte {
+	r := latticehelper.MainRing
+	moduli := r.ModuliChain()[:r.Level()+1]
+
+	b := make([]byte, 0, 8+8*len(moduli))
+	b = binary.LittleEndian.AppendUint32(b, uint32(r.N()))
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(moduli)))
+	for _, q := range moduli {
+		b = binary.LittleEndian.AppendUint64(b, q)
+	}
+
+	sum := sha256.Sum256(b)
+	return [8]byte(sum[:8])
+}
+
+func AppendHeader(b []byte, tag Tag, rows, cols int) []byte {
+	fingerprint := RingFingerprint()
+
+	b = append(b, magic[:]...)
+	b = append(b, Version, byte(tag))
+	b = append(b, fingerprint[:]...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(rows))
+	b = binary.LittleEndian.AppendUint32(b, uint32(cols))
+	return b
+}
+
+// Checks the header against tag and the current ring and returns the
+// dimensions and the remaining payload
+func ParseHeader(data []byte, tag Tag) (rows, cols int, payload []byte, err error) {
+	if len(data) < HeaderSize {
+		return 0, 0, nil, fmt.Errorf("%w: %d bytes is shorter than the header", ErrMalformed, len(data))
+	}
+	if [4]byte(data[:4]) != magic {
+		return 0, 0, nil, ErrMagic
+	}
+	if data[4] != Version {
+		return 0, 0, nil, fmt.Errorf("%w: %d", ErrVersion, data[4])
+	}
+	if Tag(data[5]) != tag {
+		return 0, 0, nil, fmt.Errorf("%w: got %v, want %v", ErrType, Tag(data[5]), tag)
+	}
+	if [8]byte(data[6:14]) != RingFingerprint() {
+		return 0, 0, nil, ErrRing
+	}
+
+	rows = int(binary.LittleEndian.Uint32(data[14:18]))
+	cols = int(binary.LittleEndian.Uint32(data[18:22]))
+	return rows, cols, data[HeaderSize:], nil
+}
+
+// Checks that payload holds exactly rows*cols polynomials of size bytes
+// each, before anything of that size is allocated
+func CheckPayload(payload []byte, rows, cols, size int) error {
+	hi, count := bits.Mul64(uint64(rows), uint64(cols))
+	hi2, total := bits.Mul64(count, uint64(size))
+	if hi != 0 || hi2 != 0 || total != uint64(len(payload)) {
+		return fmt.Errorf("%w: %dx%d polynomials do not fit %d bytes", ErrMalformed, rows, cols, len(payload))
+	}
+	return nil
+}
+
+func PolySize() int {
+	return 8 * latticehelper.MainRing.N()
+}
+
+func AppendPoly(b []byte, coeffs []int64) []byte {
+	for _, coeff := range coeffs {
+		b = binary.LittleEndian.AppendUint64(b, uint64(coeff))
+	}
+	return b
+}
+
+// Fills coeffs and returns the rest of data
+func ReadPoly(data []byte, coeffs []int64) ([]byte, error) {
+	if len(data) < 8*len(coeffs) {
+		return nil, fmt.Errorf("%w: truncated polynomial", ErrMalformed)
+	}
+	for i := range coeffs {
+		coeffs[i] = int64(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return data[8*len(coeffs):], nil
+}
+
+func coeffWidth(q uint64) int {
+	return (bits.Len64(q-1) + 7) / 8
+}
+
+func PolyQSize() int {
+	r := latticehelper.MainRing
+	size := 0
+	for _, q := range r.ModuliChain()[:r.Level()+1] {
+		size += coeffWidth(q) * r.N()
+	}
+	return size
+}
+
+// Checks that coeffs has one row of N coefficients per level of the ring
+func CheckPolyQ(coeffs [][]uint64) error {
+	r := latticehelper.MainRing
+	if len(coeffs) < r.Level()+1 {
+		return fmt.Errorf("wire: polynomial has %d levels, ring has %d", len(coeffs), r.Level()+1)
+	}
+	for _, row := range coeffs[:r.Level()+1] {
+		if len(row) != r.N() {
+			return fmt.Errorf("wire: polynomial has %d coefficients, ring degree is %d", len(row), r.N())
+		}
+	}
+	return nil
+}
+
+// Appends the coefficients of every level, coeffs[i] being reduced mod the i-th modulus
+func AppendPolyQ(b []byte, coeffs [][]uint64) []byte {
+	r := latticehelper.MainRing
+	for i, q := range r.ModuliChain()[:r.Level()+1] {
+		width := coeffWidth(q)
+		for _, coeff := range coeffs[i] {
+			for k := 0; k < width; k++ {
+				b = append(b, byte(coeff>>(8*k)))
+			}
+		}
+	}
+	return b
+}
+
+// Fills coeffs, rejecting coefficients that are not reduced, and returns the rest of data
+func ReadPolyQ(data []byte, coeffs [][]uint64) ([]byte, error) {
+	r := latticehelper.MainRing
+	for i, q := range r.ModuliChain()[:r.Level()+1] {
+		width := coeffWidth(q)
+		if len(data) < width*len(coeffs[i]) {
+			return nil, fmt.Errorf("%w: truncated polynomial", ErrMalformed)
+		}
+
+		for j := range coeffs[i] {
+			coeff := uint64(0)
+			for k := 0; k < width; k++ {
+				coeff |= uint64(data[k]) << (8 * k)
+			}
+			if coeff >= q {
+				return nil, fmt.Errorf("%w: coefficient %d is not reduced mod %d", ErrMalformed, coeff, q)
+			}
+			coeffs[i][j] = coeff
+			data = data[width:]
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,108 @@
+package wire
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/isri-pqc/latticehelper"
+)
+
+func TestMain(m *testing.M) {
+	latticehelper.InitSingle(128, 4294954753)
+	m.Run()
+}
+
+func TestHeaderRoundTrip(t *testing.T) {
+	b := AppendHeader(nil, TagPolyQMatrix, 3, 70000)
+	b = append(b, 1, 2, 3)
+
+	rows, cols, payload, err := ParseHeader(b, TagPolyQMatrix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows != 3 || cols != 70000 || len(payload) != 3 {
+		t.Errorf("got %dx%d with %d payload bytes", rows, cols, len(payload))
+	}
+}
+
+func TestHeaderErrors(t *testing.T) {
+	valid := AppendHeader(nil, TagPolyQ, 1, 1)
+
+	corrupt := func(i int, v byte) []byte {
+		b := append([]byte(nil), valid...)
+		b[i] = v
+		return b
+	}
+
+	cases := []struct {
+		name string
+		data []byte
+		tag  Tag
+		want error
+	}{
+		{"short", valid[:HeaderSize-1], TagPolyQ, ErrMalformed},
+		{"magic", corrupt(0, 'X'), TagPolyQ, ErrMagic},
+		{"version", corrupt(4, Version+1), TagPolyQ, ErrVersion},
+		{"type", valid, TagPoly, ErrType},
+		{"ring", corrupt(6, valid[6]^1), TagPolyQ, ErrRing},
+	}
+	for _, c := range cases {
+		if _, _, _, err := ParseHeader(c.data, c.tag); !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+}
+
+func TestRingFingerprintDependsOnParameters(t *testing.T) {
+	defer latticehelper.InitSingle(128, 4294954753)
+
+	before := RingFingerprint()
+	latticehelper.InitSingle(128, 12289)
+	if RingFingerprint() == before {
+		t.Error("different moduli give the same fingerprint")
+	}
+	latticehelper.InitSingle(256, 4294954753)
+	if RingFingerprint() == before {
+		t.Error("different degrees give the same fingerprint")
+	}
+}
+
+func TestCheckPayloadOverflow(t *testing.T) {
+	if err := CheckPayload(nil, 1<<32-1, 1<<32-1, 1<<20); !errors.Is(err, ErrMalformed) {
+		t.Errorf("overflowing dimensions accepted: %v", err)
+	}
+	if err := CheckPayload(make([]byte, 12), 2, 3, 2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPolyQCoefficientsRoundTrip(t *testing.T) {
+	coeffs := [][]uint64{make([]uint64, 128)}
+	for i := range coeffs[0] {
+		coeffs[0][i] = uint64(i) * 33554393
+	}
+
+	b := AppendPolyQ(nil, coeffs)
+	if len(b) != PolyQSize() || PolyQSize() != 4*128 {
+		t.Fatalf("unexpected size %d", len(b))
+	}
+
+	decoded := [][]uint64{make([]uint64, 128)}
+	rest, err := ReadPolyQ(b, decoded)
+	if err != nil || len(rest) != 0 {
+		t.Fatal(err)
+	}
+	for i := range coeffs[0] {
+		if coeffs[0][i] != decoded[0][i] {
+			t.Fatalf("coefficient %d differs", i)
+		}
+	}
+
+	// 2^32 - 1 is not reduced mod q
+	for i := range b[:4] {
+		b[i] = 0xff
+	}
+	if _, err := ReadPolyQ(b, decoded); !errors.Is(err, ErrMalformed) {
+		t.Error("unreduced coefficient accepted")
+	}
+}